@@ -1,23 +1,56 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"market/app/internal/config"
 	"market/app/internal/db"
+	"market/app/internal/events"
 	"market/app/internal/handler/ads"
 	"market/app/internal/handler/auth"
+	"market/app/internal/handler/feeds"
+	"market/app/internal/handler/gql"
+	"market/app/internal/handler/grpcapi"
 	"market/app/internal/handler/image"
+	"market/app/internal/handler/offers"
+	"market/app/internal/handler/orders"
+	"market/app/internal/handler/pages"
 	"market/app/internal/handler/reg"
+	"market/app/internal/handler/sitemap"
+	"market/app/internal/handler/static"
+	"market/app/internal/handler/stream"
+	"market/app/internal/handler/tags"
 	authmiddle "market/app/internal/middleware/auth"
+	"market/app/internal/payment/fake"
+	"market/app/internal/ratelimit"
 	"market/app/internal/repo/ads_repo"
 	"market/app/internal/repo/auth_repo"
 	"market/app/internal/repo/img_repo"
+	"market/app/internal/repo/offer_repo"
+	"market/app/internal/repo/order_repo"
 	"market/app/internal/repo/reg_repo"
+	"market/app/internal/repo/sitemap_repo"
+	"market/app/internal/repo/tag_repo"
 	"market/app/internal/router"
+	"market/app/internal/storage/local"
+	"market/app/internal/storage/s3"
 	adus "market/app/internal/usecases/ads"
 	authus "market/app/internal/usecases/auth"
 	imgus "market/app/internal/usecases/img"
+	offersus "market/app/internal/usecases/offers"
+	ordersus "market/app/internal/usecases/orders"
 	regus "market/app/internal/usecases/reg"
+	sitemapus "market/app/internal/usecases/sitemap"
+	tagsus "market/app/internal/usecases/tags"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gorilla/mux"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -26,9 +59,14 @@ import (
 	_ "market/app/internal/handler/ads/dto"
 	_ "market/app/internal/handler/auth"
 	_ "market/app/internal/handler/auth/dto"
+	_ "market/app/internal/handler/feeds/dto"
 	_ "market/app/internal/handler/image"
+	_ "market/app/internal/handler/offers/dto"
+	_ "market/app/internal/handler/orders/dto"
 	_ "market/app/internal/handler/reg"
 	_ "market/app/internal/handler/reg/dto"
+	_ "market/app/internal/handler/stream/dto"
+	_ "market/app/internal/handler/tags/dto"
 )
 
 // @title Market API
@@ -40,25 +78,85 @@ import (
 // @in header
 // @name Authorization
 func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		panic(err)
+	}
+
 	database, err := db.Connection()
 	if err != nil {
 		panic(err)
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	adsRepo := ads_repo.NewAdsRepository(database)
 	authRepo := auth_repo.NewAuthRepo(database)
 	imgRepo := img_repo.NewImgRepo(database)
 	regRepo := reg_repo.NewRegistry(database)
+	offerRepo := offer_repo.NewOfferRepo(database)
+	orderRepo := order_repo.NewOrderRepo(database)
+	tagRepo := tag_repo.NewTagRepo(database)
+	sitemapRepo := sitemap_repo.NewSitemapRepo(database)
+
+	hub := events.NewHub(64)
+
+	blobs, err := newStorage(cfg)
+	if err != nil {
+		panic(err)
+	}
 
-	imgUsecase := imgus.NewImgUsecase(imgRepo)
+	imgUsecase := imgus.NewImgUsecase(imgRepo, hub, blobs, imgus.Limits{
+		MaxPerAd:   cfg.Images.MaxPerAd,
+		QuotaBytes: cfg.Images.QuotaBytes,
+	}, imgus.Signing{
+		Secret: cfg.ImageURLs.Secret,
+		TTL:    cfg.ImageURLs.TTL,
+	})
+
+	// app gc [-dry-run] [-grace 24h] — разовая сборка мусора без запуска сервера
+	if len(os.Args) > 1 && os.Args[1] == "gc" {
+		if err := runGC(imgUsecase, cfg.GC.Grace, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := imgUsecase.CheckPrivacy(); err != nil {
+		log.Fatal(err)
+	}
+	if cfg.ImageURLs.Generated {
+		log.Println("IMAGE_URL_SECRET is not set: signed image urls will not survive a restart")
+	}
+	if cfg.GC.Interval > 0 {
+		go imgUsecase.RunGC(ctx, cfg.GC.Interval, cfg.GC.Grace)
+	}
 	authUsecase := authus.NewAuth(authRepo)
-	adsUsecase := adus.NewAds(adsRepo, imgRepo)
+	// не больше 20 показов телефона в час на пользователя
+	adsUsecase := adus.NewAds(adsRepo, imgUsecase, hub, ratelimit.NewLimiter(20, time.Hour))
 	regUsecase := regus.NewRegistry(regRepo)
+	go adsUsecase.RunScheduler(ctx, 30*time.Second)
+
+	sitemapUsecase := sitemapus.NewSitemap(sitemapRepo)
+	go sitemapUsecase.Watch(ctx, hub)
+	offersUsecase := offersus.NewOffers(offerRepo)
+	tagsUsecase := tagsus.NewTags(tagRepo)
+	// настоящего платёжного провайдера пока нет, сделки проходят через фейковый
+	ordersUsecase := ordersus.NewOrders(orderRepo, fake.NewProvider(1_000_000))
 
 	imgHandler := image.NewImageHandler(imgUsecase)
 	authHandler := auth.NewAuthHandler(authUsecase)
 	adsHandler := ads.NewAdsHandler(adsUsecase)
 	regHandler := reg.NewRegistryHandler(regUsecase)
+	streamHandler := stream.NewStreamHandler(hub)
+	offersHandler := offers.NewOffersHandler(offersUsecase)
+	ordersHandler := orders.NewOrdersHandler(ordersUsecase)
+	tagsHandler := tags.NewTagsHandler(tagsUsecase)
+	feedsHandler := feeds.NewFeedsHandler(adsUsecase)
+	pagesHandler := pages.NewPagesHandler(adsUsecase)
+	sitemapHandler := sitemap.NewSitemapHandler(sitemapUsecase)
+	graphqlHandler := gql.NewGraphQLHandler(adsUsecase, imgUsecase)
+	staticHandler := static.NewStaticHandler(blobs, imgUsecase)
 
 	authMiddleware := authmiddle.AuthMiddleware(authUsecase)
 	authOptionalMiddleware := authmiddle.OptionalAuth(authUsecase)
@@ -71,12 +169,100 @@ func main() {
 		regHandler,
 		adsHandler,
 		imgHandler,
+		streamHandler,
+		offersHandler,
+		ordersHandler,
+		tagsHandler,
+		feedsHandler,
+		pagesHandler,
+		sitemapHandler,
+		graphqlHandler,
+		staticHandler,
 		authMiddleware,
 		authOptionalMiddleware,
 	)
 
 	r.PathPrefix("/").Handler(app)
 
+	grpcSrv := grpcapi.NewServer(adsUsecase, imgUsecase, authUsecase, regUsecase)
+	lis, err := net.Listen("tcp", ":9090")
+	if err != nil {
+		log.Fatal(err)
+	}
+	go func() {
+		log.Println("gRPC server started on :9090")
+		if err := grpcSrv.Serve(lis); err != nil {
+			log.Println("grpc server failed:", err)
+		}
+	}()
+
+	srv := &http.Server{Addr: ":8080", Handler: r}
+	// закрываем подписки, иначе открытые SSE-соединения не дадут серверу остановиться
+	srv.RegisterOnShutdown(hub.Close)
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Println("server shutdown failed:", err)
+		}
+		grpcSrv.GracefulStop()
+	}()
+
 	log.Println("Server started on :8080")
-	log.Fatal(http.ListenAndServe(":8080", r))
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
+
+// runGC — подкоманда gc: удаляет файлы изображений без ссылок и печатает отчёт
+func runGC(img *imgus.ImgUsecase, grace time.Duration, args []string) error {
+	fs := flag.NewFlagSet("gc", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "only report files that would be removed")
+	fs.DurationVar(&grace, "grace", grace, "keep files younger than this")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := img.CollectGarbage(grace, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	removed, freed := "removed", "freed"
+	if report.DryRun {
+		removed, freed = "would remove", "would free"
+	}
+	for _, key := range report.Removed {
+		fmt.Println(removed, key)
+	}
+	fmt.Printf("scanned %d files, %s %d, %s %d bytes (%.1f MB)\n",
+		report.Scanned, removed, len(report.Removed), freed, report.FreedBytes, float64(report.FreedBytes)/(1<<20))
+	return nil
+}
+
+// newStorage — хранилище изображений по STORAGE_BACKEND
+func newStorage(cfg config.Config) (imgus.BlobStorage, error) {
+	if cfg.Storage == config.StorageS3 {
+		blobs, err := s3.New(s3.Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+			UseSSL:    cfg.S3.UseSSL,
+			PublicURL: cfg.S3.PublicURL,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return blobs, nil
+	}
+
+	blobs, err := local.New(cfg.UploadDir)
+	if err != nil {
+		return nil, err
+	}
+	return blobs, nil
 }
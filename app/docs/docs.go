@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/api/v1/ads": {
             "get": {
-                "description": "Возвращает список всех объявлений. Не требует авторизации, но если токен передан — отмечает ваши объявления как ` + "`" + `is_owner=true` + "`" + `.",
+                "description": "Возвращает список всех объявлений. Не требует авторизации, но если токен передан — отмечает ваши объявления как ` + "`" + `is_owner=true` + "`" + `. Некорректный параметр — 400 с его именем в сообщении.",
                 "consumes": [
                     "application/json"
                 ],
@@ -31,7 +31,7 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Ограничение по количеству",
+                        "description": "Ограничение по количеству (1–100, по умолчанию 10)",
                         "name": "limit",
                         "in": "query"
                     },
@@ -43,13 +43,13 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Поле для сортировки",
+                        "description": "Ключи сортировки через запятую: created_at, price, title; префикс - по убыванию, + по возрастанию",
                         "name": "sort",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "asc или desc",
+                        "description": "asc или desc — направление для ключей без префикса",
                         "name": "order",
                         "in": "query"
                     },
@@ -64,6 +64,53 @@ const docTemplate = `{
                         "description": "Максимальная цена",
                         "name": "max",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "budget",
+                            "mid",
+                            "premium"
+                        ],
+                        "type": "string",
+                        "description": "Диапазон цен: budget (до 1000), mid (1000–10000) или premium (от 10000); min и max сужают его",
+                        "name": "price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID автора",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Созданы не раньше (RFC 3339 или YYYY-MM-DD)",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Созданы раньше (RFC 3339 или YYYY-MM-DD)",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только с изображениями / только без",
+                        "name": "has_images",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Теги через запятую",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "any (хотя бы один тег, по умолчанию) или all (все теги)",
+                        "name": "tags_mode",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -99,7 +146,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Создает новое объявление. Требует авторизации.",
+                "description": "Создает новое объявление. Требует авторизации. С ` + "`" + `publish_at` + "`" + ` объявление остаётся скрытым от других пользователей до указанного времени. Теги нормализуются (нижний регистр, одиночные пробелы), не больше 10 тегов по 30 символов. ` + "`" + `contact_phone` + "`" + ` — телефон для этого объявления, без него используется телефон из профиля. ` + "`" + `quantity` + "`" + ` — число одинаковых единиц товара (по умолчанию 1, максимум 10000).",
                 "consumes": [
                     "application/json"
                 ],
@@ -151,7 +198,7 @@ const docTemplate = `{
         },
         "/api/v1/ads/images/{id}": {
             "get": {
-                "description": "Возвращает одно изображение по его ID",
+                "description": "Возвращает одно изображение по его ID. Закрытое изображение видно только с токеном.",
                 "produces": [
                     "application/json"
                 ],
@@ -181,16 +228,78 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Err400BadRequest"
                         }
                     },
+                    "401": {
+                        "description": "Изображение закрыто, нужен токен",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err401PrivateImages"
+                        }
+                    },
+                    "404": {
+                        "description": "Изображение не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err404AdNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err500Internal"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет изображение объявления вместе с файлом. Только владелец объявления. Требует авторизации.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "image"
+                ],
+                "summary": "Удалить изображение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID изображения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err400BadRequest"
+                        }
+                    },
                     "401": {
                         "description": "Пользователь не авторизован",
                         "schema": {
                             "$ref": "#/definitions/dto.Err401Unauthorized"
                         }
                     },
+                    "403": {
+                        "description": "Изображение чужого объявления",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err403Forbidden"
+                        }
+                    },
                     "404": {
                         "description": "Изображение не найдено",
                         "schema": {
-                            "$ref": "#/definitions/dto.Err404AdNotFound"
+                            "$ref": "#/definitions/dto.Err404ImageNotFound"
                         }
                     },
                     "500": {
@@ -311,9 +420,137 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/ads/{id}/contact/reveal": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает полный номер продавца. Число показов ограничено для каждого пользователя, каждый показ учитывается в статистике владельца объявления.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ads"
+                ],
+                "summary": "Показать телефон продавца",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID объявления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContactRevealDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse401"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse404"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse429"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/ads/{id}/contact/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сколько раз и сколько разных пользователей открывали телефон по объявлению. Только для владельца.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ads"
+                ],
+                "summary": "Статистика показов телефона",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID объявления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContactStatsDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse401"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse500"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/ads/{id}/images": {
             "get": {
-                "description": "Возвращает список изображений, прикреплённых к объявлению",
+                "description": "Возвращает список изображений, прикреплённых к объявлению. Закрытые изображения видны только с токеном и отдаются по подписанным ссылкам с ограниченным сроком.",
                 "produces": [
                     "application/json"
                 ],
@@ -347,9 +584,9 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Пользователь не авторизован",
+                        "description": "Изображения закрыты, нужен токен",
                         "schema": {
-                            "$ref": "#/definitions/dto.Err401Unauthorized"
+                            "$ref": "#/definitions/dto.Err401PrivateImages"
                         }
                     },
                     "404": {
@@ -372,7 +609,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Загружает изображение в формате JPEG или PNG для указанного объявления. Требует авторизации.",
+                "description": "Загружает изображение в формате JPEG или PNG для указанного объявления, перекодирует его без метаданных (EXIF) и создаёт уменьшенные копии (` + "`" + `variants` + "`" + `: thumb 200px, medium 800px, original). Только автор объявления. Число изображений в объявлении и суммарный размер изображений пользователя ограничены. Требует авторизации.",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -407,7 +644,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Невалидный файл или данные",
+                        "description": "Невалидный файл или данные, изображение не декодируется",
                         "schema": {
                             "$ref": "#/definitions/dto.Err400BadRequest"
                         }
@@ -418,12 +655,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Err401Unauthorized"
                         }
                     },
+                    "403": {
+                        "description": "Объявление другого пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err403Forbidden"
+                        }
+                    },
                     "404": {
                         "description": "Объявление не найдено",
                         "schema": {
                             "$ref": "#/definitions/dto.Err404AdNotFound"
                         }
                     },
+                    "409": {
+                        "description": "В объявлении уже максимум изображений",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err409ImageLimit"
+                        }
+                    },
+                    "413": {
+                        "description": "Превышена квота пользователя на место",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err413StorageQuota"
+                        }
+                    },
                     "415": {
                         "description": "Неподдерживаемый тип файла",
                         "schema": {
@@ -439,9 +694,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/login": {
-            "post": {
-                "description": "Авторизует пользователя по email и паролю и возвращает токен",
+        "/api/v1/ads/{id}/images/order": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Принимает полный список ID изображений объявления в нужном порядке; первое изображение становится обложкой в ленте. Список должен содержать каждое изображение объявления ровно один раз. Только владелец объявления. Требует авторизации.",
                 "consumes": [
                     "application/json"
                 ],
@@ -449,56 +709,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "image"
                 ],
-                "summary": "Вход пользователя",
+                "summary": "Изменить порядок изображений",
                 "parameters": [
                     {
-                        "description": "Данные для входа",
-                        "name": "credentials",
+                        "type": "string",
+                        "description": "ID объявления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый порядок изображений",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginRequestDTO"
+                            "$ref": "#/definitions/dto.ReorderRequestDTO"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Изображения в новом порядке",
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginResponseDTO"
+                            "$ref": "#/definitions/dto.ImagesResponseDTO"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Некорректный ID или список не совпадает с изображениями объявления",
                         "schema": {
-                            "$ref": "#/definitions/dto.Err400"
+                            "$ref": "#/definitions/dto.Err400InvalidOrder"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Пользователь не авторизован",
                         "schema": {
-                            "$ref": "#/definitions/dto.Err401"
+                            "$ref": "#/definitions/dto.Err401Unauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Объявление другого пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err403Forbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Объявление не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err404AdNotFound"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/dto.Err500"
+                            "$ref": "#/definitions/dto.Err500Internal"
                         }
                     }
                 }
             }
         },
-        "/api/v1/logout": {
-            "post": {
+        "/api/v1/ads/{id}/images/privacy": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаляет сессию пользователя. Требует авторизации (по токену).",
+                "description": "С ` + "`" + `private=true` + "`" + ` изображения объявления видны только авторизованным пользователям: ссылки на них подписаны и действуют ограниченное время, без подписи файл не отдаётся. Только владелец объявления. Требует авторизации.",
                 "consumes": [
                     "application/json"
                 ],
@@ -506,31 +785,137 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "image"
+                ],
+                "summary": "Закрыть или открыть изображения объявления",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID объявления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Закрыть или открыть",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PrivacyRequestDTO"
+                        }
+                    }
                 ],
-                "summary": "Выход пользователя",
                 "responses": {
                     "200": {
-                        "description": "Успешный выход из системы"
+                        "description": "Изображения с новыми ссылками",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImagesResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID или тело запроса",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err400BadRequest"
+                        }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Пользователь не авторизован",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrResponse401Unauthorized"
+                            "$ref": "#/definitions/dto.Err401Unauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Объявление другого пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err403Forbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Объявление не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err404AdNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Файлы отдаются по публичной ссылке хранилища, закрыть их нельзя",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err409PrivateUnavailable"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/dto.Err500"
+                            "$ref": "#/definitions/dto.Err500Internal"
                         }
                     }
                 }
             }
         },
-        "/api/v1/register": {
+        "/api/v1/ads/{id}/offers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Владелец объявления видит все предложения, остальные — только свои. Требует авторизации.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offers"
+                ],
+                "summary": "Предложения по объявлению",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID объявления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OffersResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer401"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer500"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Регистрирует нового пользователя по имени, email и паролю",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Покупатель предлагает свою цену за объявление. Предложение действует 72 часа. Требует авторизации.",
                 "consumes": [
                     "application/json"
                 ],
@@ -538,215 +923,2168 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "offers"
                 ],
-                "summary": "Регистрация пользователя",
+                "summary": "Предложить цену",
                 "parameters": [
                     {
-                        "description": "Данные для регистрации",
-                        "name": "user",
+                        "type": "string",
+                        "description": "ID объявления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Предложение",
+                        "name": "offer",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.RegUserRequestDTO"
+                            "$ref": "#/definitions/dto.OfferCreateDTO"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Пользователь успешно зарегистрирован",
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.RegUserResponseDTO"
+                            "$ref": "#/definitions/dto.OfferResponseDTO"
                         }
                     },
                     "400": {
-                        "description": "Некорректный JSON или поля",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrDTO400"
+                            "$ref": "#/definitions/dto.ErrOffer400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer401"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer404"
                         }
                     },
                     "409": {
-                        "description": "Email уже существует",
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrDTO409"
+                            "$ref": "#/definitions/dto.ErrOffer409"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrDTO500"
+                            "$ref": "#/definitions/dto.ErrOffer500"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.AdCreateRespDTO": {
-            "type": "object",
-            "properties": {
-                "author_id": {
-                    "type": "string",
-                    "example": "a491c857-dbd0-4a4a-88dc-123456789abc"
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2025-07-20T12:34:56Z"
-                },
-                "description": {
-                    "type": "string",
-                    "example": "Горный велосипед в хорошем состоянии"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "b72f25de-3ef1-4a36-9675-df31545fa08c"
-                },
-                "price": {
-                    "type": "number",
-                    "example": 5000
-                },
-                "title": {
-                    "type": "string",
-                    "example": "Велосипед"
+        },
+        "/api/v1/ads/{id}/orders": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Покупатель оформляет заказ по объявлению, деньги блокируются у платёжного провайдера. Цена — из принятого предложения, если оно есть, иначе цена объявления. По распроданному объявлению — 409. Требует авторизации.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Оформить заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID объявления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder401"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder402"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder404"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder409"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder500"
+                        }
+                    }
                 }
             }
         },
-        "dto.AdDetailedResponseDTO": {
-            "type": "object",
-            "properties": {
-                "author_id": {
-                    "type": "string",
-                    "example": "a491c857-dbd0-4a4a-88dc-123456789abc"
-                },
-                "author_name": {
-                    "type": "string",
-                    "example": "Иван"
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2025-07-20T12:34:56Z"
-                },
-                "description": {
-                    "type": "string",
-                    "example": "Горный велосипед в хорошем состоянии"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "b72f25de-3ef1-4a36-9675-df31545fa08c"
-                },
-                "images": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "['/static/upload/1.jpg'",
-                        "'/static/upload/2.png']"
-                    ]
-                },
-                "is_owner": {
-                    "type": "boolean",
+        "/api/v1/ads/{id}/similar": {
+            "get": {
+                "description": "Объявления других авторов, похожие по заголовку и описанию, с близкой ценой; свежие выше. Результат кэшируется на несколько минут.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ads"
+                ],
+                "summary": "Похожие объявления",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID объявления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество (по умолчанию 10, максимум 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdsResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse400"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/ads/{id}/sold": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Продавец уменьшает остаток товара на units. Списание атомарное: при параллельных запросах остаток не уходит в минус, а при нуле объявление становится распроданным (` + "`" + `sold_out=true` + "`" + `). Требует авторизации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ads"
+                ],
+                "summary": "Отметить проданные единицы",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID объявления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Сколько единиц продано",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SellUnitsDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse401"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse404"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse409"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events: новые объявления, удаления и новые изображения. С ` + "`" + `mine=true` + "`" + ` — только события по вашим объявлениям (нужен токен).",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Поток событий ленты (SSE)",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только мои объявления",
+                        "name": "mine",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EventDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrEvents400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrEvents401"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrEvents500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/events/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Те же события, что и в SSE, но через WebSocket. С ` + "`" + `mine=true` + "`" + ` — только события по вашим объявлениям (нужен токен).",
+                "tags": [
+                    "events"
+                ],
+                "summary": "Поток событий ленты (WebSocket)",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только мои объявления",
+                        "name": "mine",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EventDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrEvents400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrEvents401"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/feeds/ads.atom": {
+            "get": {
+                "description": "Atom 1.0 с последними объявлениями. Принимает те же параметры, что и GET /api/v1/ads, изображения — ссылки rel=\"enclosure\". Поддерживает If-None-Match и If-Modified-Since.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Лента объявлений в Atom",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ограничение по количеству (1–100, по умолчанию 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключи сортировки через запятую: created_at, price, title",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная цена",
+                        "name": "min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальная цена",
+                        "name": "max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID автора",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только с изображениями / только без",
+                        "name": "has_images",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Теги через запятую",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "any или all",
+                        "name": "tags_mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Atom 1.0",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Лента не изменилась"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrFeed400"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrFeed500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/feeds/ads.rss": {
+            "get": {
+                "description": "RSS 2.0 с последними объявлениями. Принимает те же параметры, что и GET /api/v1/ads, первое изображение — enclosure. Поддерживает If-None-Match и If-Modified-Since.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Лента объявлений в RSS",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ограничение по количеству (1–100, по умолчанию 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключи сортировки через запятую: created_at, price, title",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная цена",
+                        "name": "min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальная цена",
+                        "name": "max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID автора",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только с изображениями / только без",
+                        "name": "has_images",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Теги через запятую",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "any или all",
+                        "name": "tags_mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "RSS 2.0",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Лента не изменилась"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrFeed400"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrFeed500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/login": {
+            "post": {
+                "description": "Авторизует пользователя по email и паролю и возвращает токен",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Вход пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные для входа",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err401"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет сессию пользователя. Требует авторизации (по токену).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Выход пользователя",
+                "responses": {
+                    "200": {
+                        "description": "Успешный выход из системы"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrResponse401Unauthorized"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/storage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сколько байт занимают изображения во всех объявлениях пользователя (оригиналы и копии), квота и лимит изображений на объявление; 0 — без ограничения. Требует авторизации.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "image"
+                ],
+                "summary": "Занятое место под изображения",
+                "responses": {
+                    "200": {
+                        "description": "Использование и лимиты",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StorageUsageDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err401Unauthorized"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Err500Internal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/offers/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Продавец принимает предложение покупателя или покупатель — встречное предложение продавца. Остальные открытые предложения по объявлению отклоняются. С ` + "`" + `hide_ad=true` + "`" + ` объявление скрывается из ленты. Требует авторизации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offers"
+                ],
+                "summary": "Принять предложение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID предложения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Параметры",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OfferAcceptDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OfferResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer401"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer404"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer409"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer410"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/offers/{id}/counter": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Продавец отвечает на предложение своей ценой. ` + "`" + `hide_ad` + "`" + ` — скрыть объявление из ленты, если покупатель примет встречную цену. Требует авторизации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offers"
+                ],
+                "summary": "Встречное предложение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID предложения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Встречное предложение",
+                        "name": "counter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OfferCounterDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OfferResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer401"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer404"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer409"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer410"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/offers/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Продавец отклоняет предложение покупателя или покупатель — встречное предложение продавца. Требует авторизации.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offers"
+                ],
+                "summary": "Отклонить предложение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID предложения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OfferResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer401"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer404"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer409"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer410"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOffer500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заказы, в которых вы покупатель или продавец. Требует авторизации.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Мои заказы",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrdersResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder401"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает заказ вместе с историей переходов между статусами. Доступно покупателю и продавцу.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Получить заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderDetailedResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder401"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder404"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Покупатель или продавец отменяет заказ до его завершения, заблокированные деньги возвращаются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Отменить заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder401"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder402"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder404"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder409"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Продавец подтверждает заказ после того, как деньги покупателя заблокированы.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Подтвердить заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder401"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder404"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder409"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Покупатель подтверждает получение товара, деньги списываются в пользу продавца.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Подтвердить получение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder400"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder401"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder402"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder403"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder404"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder409"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrOrder500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/register": {
+            "post": {
+                "description": "Регистрирует нового пользователя по имени, email и паролю. Телефон необязателен, он показывается покупателям замаскированным.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Регистрация пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные для регистрации",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegUserRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Пользователь успешно зарегистрирован",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegUserResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный JSON или поля",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrDTO400"
+                        }
+                    },
+                    "409": {
+                        "description": "Email уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrDTO409"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrDTO500"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tags/suggest": {
+            "get": {
+                "description": "Теги, начинающиеся с prefix, отсортированные по числу объявлений с ними",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Подсказки тегов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало тега",
+                        "name": "prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество (по умолчанию 10, максимум 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagsResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrTags400"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrTags500"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Запросы ads/ad и мутации createAd/deleteAd/uploadImage, схема — internal/handler/gql/schema.graphql. Токен передаётся так же, как в REST (Bearer). Файлы загружаются по спецификации GraphQL multipart request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "GraphQL",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AdCreateRespDTO": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string",
+                    "example": "a491c857-dbd0-4a4a-88dc-123456789abc"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-20T12:34:56Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Горный велосипед в хорошем состоянии"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b72f25de-3ef1-4a36-9675-df31545fa08c"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "publish_at": {
+                    "type": "string",
+                    "example": "2025-07-21T08:00:00Z"
+                },
+                "published": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "sold_out": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "винтаж",
+                        "торг"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Велосипед"
+                }
+            }
+        },
+        "dto.AdDetailedResponseDTO": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string",
+                    "example": "a491c857-dbd0-4a4a-88dc-123456789abc"
+                },
+                "author_name": {
+                    "type": "string",
+                    "example": "Иван"
+                },
+                "contact_phone": {
+                    "type": "string",
+                    "example": "+7*******67"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-20T12:34:56Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Горный велосипед в хорошем состоянии"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b72f25de-3ef1-4a36-9675-df31545fa08c"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "['/static/upload/1.jpg'",
+                        "'/static/upload/2.png']"
+                    ]
+                },
+                "is_owner": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "price": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "publish_at": {
+                    "type": "string",
+                    "example": "2025-07-21T08:00:00Z"
+                },
+                "published": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "sold_out": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "винтаж",
+                        "торг"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Велосипед"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImageVariantsDTO"
+                    }
+                }
+            }
+        },
+        "dto.AdResponseDTO": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string",
+                    "example": "a491c857-dbd0-4a4a-88dc-123456789abc"
+                },
+                "author_name": {
+                    "type": "string",
+                    "example": "Иван"
+                },
+                "cover": {
+                    "type": "string",
+                    "example": "/static/upload/1.jpg"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-20T12:34:56Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Горный велосипед в хорошем состоянии"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b72f25de-3ef1-4a36-9675-df31545fa08c"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "['/static/upload/1.jpg'",
+                        "'/static/upload/2.png']"
+                    ]
+                },
+                "is_owner": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "price": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "publish_at": {
+                    "type": "string",
+                    "example": "2025-07-21T08:00:00Z"
+                },
+                "published": {
+                    "type": "boolean",
                     "example": true
                 },
-                "price": {
-                    "type": "number",
-                    "example": 5000
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "sold_out": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "винтаж",
+                        "торг"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Велосипед"
+                }
+            }
+        },
+        "dto.AdsCreateDTO": {
+            "type": "object",
+            "properties": {
+                "contact_phone": {
+                    "type": "string",
+                    "example": "+79991234567"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Горный велосипед в хорошем состоянии"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "publish_at": {
+                    "type": "string",
+                    "example": "2025-07-21T08:00:00Z"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "винтаж",
+                        "торг"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Велосипед"
+                }
+            }
+        },
+        "dto.AdsResponseDTO": {
+            "type": "object",
+            "properties": {
+                "ads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AdResponseDTO"
+                    }
+                }
+            }
+        },
+        "dto.ContactRevealDTO": {
+            "type": "object",
+            "properties": {
+                "phone": {
+                    "type": "string",
+                    "example": "+79991234567"
+                }
+            }
+        },
+        "dto.ContactStatsDTO": {
+            "type": "object",
+            "properties": {
+                "last_at": {
+                    "type": "string",
+                    "example": "2025-07-20T12:34:56Z"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "unique_users": {
+                    "type": "integer",
+                    "example": 9
+                }
+            }
+        },
+        "dto.Err400": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid JSON"
+                }
+            }
+        },
+        "dto.Err400BadRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "message": {
+                    "type": "string",
+                    "example": "bad request"
+                }
+            }
+        },
+        "dto.Err400InvalidOrder": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "message": {
+                    "type": "string",
+                    "example": "image ids must list every image of the ad exactly once"
+                }
+            }
+        },
+        "dto.Err401": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 401
+                },
+                "message": {
+                    "type": "string",
+                    "example": "incorrect email or password"
+                }
+            }
+        },
+        "dto.Err401PrivateImages": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 401
+                },
+                "message": {
+                    "type": "string",
+                    "example": "images of this ad are visible only to logged-in users"
+                }
+            }
+        },
+        "dto.Err401Unauthorized": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 401
+                },
+                "message": {
+                    "type": "string",
+                    "example": "unauthorized"
+                }
+            }
+        },
+        "dto.Err403Forbidden": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 403
+                },
+                "message": {
+                    "type": "string",
+                    "example": "you are not the owner of this ad"
+                }
+            }
+        },
+        "dto.Err404AdNotFound": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "message": {
+                    "type": "string",
+                    "example": "ad not found"
+                }
+            }
+        },
+        "dto.Err404ImageNotFound": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "message": {
+                    "type": "string",
+                    "example": "image not found"
+                }
+            }
+        },
+        "dto.Err409ImageLimit": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 409
+                },
+                "message": {
+                    "type": "string",
+                    "example": "ad already has the maximum number of images"
+                }
+            }
+        },
+        "dto.Err409PrivateUnavailable": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 409
+                },
+                "message": {
+                    "type": "string",
+                    "example": "private images are unavailable while storage serves files from a public url"
+                }
+            }
+        },
+        "dto.Err413StorageQuota": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 413
                 },
-                "title": {
+                "message": {
                     "type": "string",
-                    "example": "Велосипед"
+                    "example": "storage quota exceeded"
+                }
+            }
+        },
+        "dto.Err415": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 415
+                },
+                "message": {
+                    "type": "string",
+                    "example": "unsupported content type"
+                }
+            }
+        },
+        "dto.Err500": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "message": {
+                    "type": "string",
+                    "example": "internal server error"
+                }
+            }
+        },
+        "dto.Err500Internal": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "message": {
+                    "type": "string",
+                    "example": "internal server error"
+                }
+            }
+        },
+        "dto.ErrDTO400": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid json"
+                }
+            }
+        },
+        "dto.ErrDTO409": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 409
+                },
+                "message": {
+                    "type": "string",
+                    "example": "email already exists"
+                }
+            }
+        },
+        "dto.ErrDTO500": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "message": {
+                    "type": "string",
+                    "example": "internal server error"
+                }
+            }
+        },
+        "dto.ErrEvents400": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "message": {
+                    "type": "string",
+                    "example": "mine must be true or false"
+                }
+            }
+        },
+        "dto.ErrEvents401": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 401
+                },
+                "message": {
+                    "type": "string",
+                    "example": "unauthorized"
+                }
+            }
+        },
+        "dto.ErrEvents500": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "message": {
+                    "type": "string",
+                    "example": "streaming unsupported"
+                }
+            }
+        },
+        "dto.ErrFeed400": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid limit: must be an integer"
+                }
+            }
+        },
+        "dto.ErrFeed500": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "message": {
+                    "type": "string",
+                    "example": "internal server error"
+                }
+            }
+        },
+        "dto.ErrImagesNotFoundExample": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "image not found"
+                }
+            }
+        },
+        "dto.ErrOffer400": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "message": {
+                    "type": "string",
+                    "example": "offer amount is invalid"
+                }
+            }
+        },
+        "dto.ErrOffer401": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 401
+                },
+                "message": {
+                    "type": "string",
+                    "example": "unauthorized"
+                }
+            }
+        },
+        "dto.ErrOffer403": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 403
+                },
+                "message": {
+                    "type": "string",
+                    "example": "you are not allowed to act on this offer"
+                }
+            }
+        },
+        "dto.ErrOffer404": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "message": {
+                    "type": "string",
+                    "example": "offer not found"
+                }
+            }
+        },
+        "dto.ErrOffer409": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 409
+                },
+                "message": {
+                    "type": "string",
+                    "example": "offer cannot move to this state"
+                }
+            }
+        },
+        "dto.ErrOffer410": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 410
+                },
+                "message": {
+                    "type": "string",
+                    "example": "offer expired"
+                }
+            }
+        },
+        "dto.ErrOffer500": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "message": {
+                    "type": "string",
+                    "example": "internal server error"
                 }
             }
         },
-        "dto.AdResponseDTO": {
+        "dto.ErrOrder400": {
             "type": "object",
             "properties": {
-                "author_id": {
-                    "type": "string",
-                    "example": "a491c857-dbd0-4a4a-88dc-123456789abc"
-                },
-                "author_name": {
-                    "type": "string",
-                    "example": "Иван"
+                "code": {
+                    "type": "integer",
+                    "example": 400
                 },
-                "created_at": {
+                "message": {
                     "type": "string",
-                    "example": "2025-07-20T12:34:56Z"
+                    "example": "cannot order your own ad"
+                }
+            }
+        },
+        "dto.ErrOrder401": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 401
                 },
-                "description": {
+                "message": {
                     "type": "string",
-                    "example": "Горный велосипед в хорошем состоянии"
+                    "example": "unauthorized"
+                }
+            }
+        },
+        "dto.ErrOrder402": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 402
                 },
-                "id": {
+                "message": {
                     "type": "string",
-                    "example": "b72f25de-3ef1-4a36-9675-df31545fa08c"
-                },
-                "images": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "['/static/upload/1.jpg'",
-                        "'/static/upload/2.png']"
-                    ]
-                },
-                "is_owner": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "price": {
-                    "type": "number",
-                    "example": 5000
+                    "example": "payment failed"
+                }
+            }
+        },
+        "dto.ErrOrder403": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 403
                 },
-                "title": {
+                "message": {
                     "type": "string",
-                    "example": "Велосипед"
+                    "example": "you are not allowed to act on this order"
                 }
             }
         },
-        "dto.AdsCreateDTO": {
+        "dto.ErrOrder404": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string",
-                    "example": "Горный велосипед в хорошем состоянии"
-                },
-                "price": {
-                    "type": "number",
-                    "example": 5000
+                "code": {
+                    "type": "integer",
+                    "example": 404
                 },
-                "title": {
+                "message": {
                     "type": "string",
-                    "example": "Велосипед"
+                    "example": "order not found"
                 }
             }
         },
-        "dto.AdsResponseDTO": {
+        "dto.ErrOrder409": {
             "type": "object",
             "properties": {
-                "ads": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.AdResponseDTO"
-                    }
+                "code": {
+                    "type": "integer",
+                    "example": 409
+                },
+                "message": {
+                    "type": "string",
+                    "example": "order cannot move to this state"
                 }
             }
         },
-        "dto.Err400": {
+        "dto.ErrOrder500": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 400
+                    "example": 500
                 },
                 "message": {
                     "type": "string",
-                    "example": "invalid JSON"
+                    "example": "internal server error"
                 }
             }
         },
-        "dto.Err400BadRequest": {
+        "dto.ErrResponse400": {
             "type": "object",
             "properties": {
                 "code": {
@@ -755,11 +3093,11 @@ const docTemplate = `{
                 },
                 "message": {
                     "type": "string",
-                    "example": "bad request"
+                    "example": "Bad Request"
                 }
             }
         },
-        "dto.Err401": {
+        "dto.ErrResponse401": {
             "type": "object",
             "properties": {
                 "code": {
@@ -768,11 +3106,11 @@ const docTemplate = `{
                 },
                 "message": {
                     "type": "string",
-                    "example": "incorrect email or password"
+                    "example": "unauthorized"
                 }
             }
         },
-        "dto.Err401Unauthorized": {
+        "dto.ErrResponse401Unauthorized": {
             "type": "object",
             "properties": {
                 "code": {
@@ -785,85 +3123,110 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.Err404AdNotFound": {
+        "dto.ErrResponse403": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 404
+                    "example": 403
                 },
                 "message": {
                     "type": "string",
-                    "example": "ad not found"
+                    "example": "you are not owner"
                 }
             }
         },
-        "dto.Err415": {
+        "dto.ErrResponse404": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 415
+                    "example": 404
                 },
                 "message": {
                     "type": "string",
-                    "example": "unsupported content type"
+                    "example": "Not Found"
                 }
             }
         },
-        "dto.Err500": {
+        "dto.ErrResponse404ArrExample": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "in: body",
+                    "type": "object",
+                    "properties": {
+                        "code": {
+                            "type": "integer",
+                            "example": 404
+                        },
+                        "data": {
+                            "type": "array",
+                            "items": {
+                                "type": "object"
+                            }
+                        },
+                        "message": {
+                            "type": "string",
+                            "example": "Not Found"
+                        }
+                    }
+                }
+            }
+        },
+        "dto.ErrResponse409": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 500
+                    "example": 409
                 },
                 "message": {
                     "type": "string",
-                    "example": "internal server error"
+                    "example": "not enough units in stock"
                 }
             }
         },
-        "dto.Err500Internal": {
+        "dto.ErrResponse429": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 500
+                    "example": 429
                 },
                 "message": {
                     "type": "string",
-                    "example": "internal server error"
+                    "example": "too many contact reveals: retry in 42m0s"
                 }
             }
         },
-        "dto.ErrDTO400": {
+        "dto.ErrResponse500": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 400
+                    "example": 500
                 },
                 "message": {
                     "type": "string",
-                    "example": "invalid json"
+                    "example": "internal server error"
                 }
             }
         },
-        "dto.ErrDTO409": {
+        "dto.ErrTags400": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 409
+                    "example": 400
                 },
                 "message": {
                     "type": "string",
-                    "example": "email already exists"
+                    "example": "invalid limit: must be an integer"
                 }
             }
         },
-        "dto.ErrDTO500": {
+        "dto.ErrTags500": {
             "type": "object",
             "properties": {
                 "code": {
@@ -876,147 +3239,376 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.ErrImagesNotFoundExample": {
+        "dto.EventAdDTO": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string",
+                    "example": "a491c857-dbd0-4a4a-88dc-123456789abc"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-20T12:34:56Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Горный велосипед в хорошем состоянии"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b72f25de-3ef1-4a36-9675-df31545fa08c"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Велосипед"
+                }
+            }
+        },
+        "dto.EventDTO": {
+            "type": "object",
+            "properties": {
+                "ad": {
+                    "$ref": "#/definitions/dto.EventAdDTO"
+                },
+                "ad_id": {
+                    "type": "string",
+                    "example": "b72f25de-3ef1-4a36-9675-df31545fa08c"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-20T12:34:56Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "image": {
+                    "$ref": "#/definitions/dto.EventImageDTO"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "ad_created"
+                }
+            }
+        },
+        "dto.EventImageDTO": {
+            "type": "object",
+            "properties": {
+                "adId": {
+                    "type": "string",
+                    "example": "92d1b029-10b6-4df4-8463-b3272e4f15ee"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-07-20T12:34:56Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f8c7e2a1-72e1-4c9e-bd84-7ae1b8fc4d4b"
+                },
+                "imageUrl": {
+                    "type": "string",
+                    "example": "/static/upload/example.jpg"
+                },
+                "private": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "variants": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "medium": "/static/upload/example_medium.jpg",
+                        "original": "/static/upload/example.jpg",
+                        "thumb": "/static/upload/example_thumb.jpg"
+                    }
+                }
+            }
+        },
+        "dto.ImageVariantsDTO": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "3d6f8a10-5b2c-4e7d-9f1a-2c4b6d8e0f13"
+                },
+                "variants": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "medium": "/static/upload/example_medium.jpg",
+                        "original": "/static/upload/example.jpg",
+                        "thumb": "/static/upload/example_thumb.jpg"
+                    }
+                }
+            }
+        },
+        "dto.ImagesResponseDTO": {
+            "type": "object",
+            "properties": {
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                }
+            }
+        },
+        "dto.LoginRequestDTO": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 404
-                },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "type": "object"
-                    }
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
                 },
-                "message": {
+                "password": {
                     "type": "string",
-                    "example": "image not found"
+                    "example": "my_secure_password"
                 }
             }
         },
-        "dto.ErrResponse400": {
+        "dto.LoginResponseDTO": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 400
-                },
-                "message": {
+                "token": {
                     "type": "string",
-                    "example": "Bad Request"
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
                 }
             }
         },
-        "dto.ErrResponse401": {
+        "dto.OfferAcceptDTO": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 401
-                },
-                "message": {
-                    "type": "string",
-                    "example": "unauthorized"
+                "hide_ad": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "dto.ErrResponse401Unauthorized": {
+        "dto.OfferCounterDTO": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 401
+                "amount": {
+                    "type": "number",
+                    "example": 4800
+                },
+                "hide_ad": {
+                    "type": "boolean",
+                    "example": true
                 },
                 "message": {
                     "type": "string",
-                    "example": "unauthorized"
+                    "example": "Могу уступить до 4800"
                 }
             }
         },
-        "dto.ErrResponse403": {
+        "dto.OfferCreateDTO": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 403
+                "amount": {
+                    "type": "number",
+                    "example": 4500
                 },
                 "message": {
                     "type": "string",
-                    "example": "you are not owner"
+                    "example": "Заберу сегодня вечером"
                 }
             }
         },
-        "dto.ErrResponse404": {
+        "dto.OfferResponseDTO": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 404
+                "ad_id": {
+                    "type": "string",
+                    "example": "b72f25de-3ef1-4a36-9675-df31545fa08c"
+                },
+                "amount": {
+                    "type": "number",
+                    "example": 4500
+                },
+                "buyer_id": {
+                    "type": "string",
+                    "example": "a491c857-dbd0-4a4a-88dc-123456789abc"
+                },
+                "counter_amount": {
+                    "type": "number",
+                    "example": 4800
+                },
+                "counter_message": {
+                    "type": "string",
+                    "example": "Могу уступить до 4800"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-20T12:34:56Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-07-23T12:34:56Z"
+                },
+                "hide_ad": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0c5e4a0e-8a1f-4a8e-9d57-3c2b1c1b9f10"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Not Found"
+                    "example": "Заберу сегодня вечером"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-07-20T12:34:56Z"
                 }
             }
         },
-        "dto.ErrResponse404ArrExample": {
+        "dto.OffersResponseDTO": {
             "type": "object",
             "properties": {
-                "body": {
-                    "description": "in: body",
-                    "type": "object",
-                    "properties": {
-                        "code": {
-                            "type": "integer",
-                            "example": 404
-                        },
-                        "data": {
-                            "type": "array",
-                            "items": {
-                                "type": "object"
-                            }
-                        },
-                        "message": {
-                            "type": "string",
-                            "example": "Not Found"
-                        }
+                "offers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OfferResponseDTO"
                     }
                 }
             }
         },
-        "dto.ErrResponse500": {
+        "dto.OrderDetailedResponseDTO": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 500
+                "ad_id": {
+                    "type": "string",
+                    "example": "b72f25de-3ef1-4a36-9675-df31545fa08c"
                 },
-                "message": {
+                "amount": {
+                    "type": "number",
+                    "example": 4500
+                },
+                "buyer_id": {
                     "type": "string",
-                    "example": "internal server error"
+                    "example": "a491c857-dbd0-4a4a-88dc-123456789abc"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-20T12:34:56Z"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OrderEventDTO"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5d0f5c7e-2b1a-4f43-9a3e-6f1c0b9e2d11"
+                },
+                "seller_id": {
+                    "type": "string",
+                    "example": "c1a2b3c4-d5e6-47f8-9a0b-1c2d3e4f5a6b"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "authorized"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-07-20T12:34:56Z"
                 }
             }
         },
-        "dto.LoginRequestDTO": {
+        "dto.OrderEventDTO": {
             "type": "object",
             "properties": {
-                "email": {
+                "actor_id": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "c1a2b3c4-d5e6-47f8-9a0b-1c2d3e4f5a6b"
                 },
-                "password": {
+                "created_at": {
                     "type": "string",
-                    "example": "my_secure_password"
+                    "example": "2025-07-20T12:34:56Z"
+                },
+                "from_status": {
+                    "type": "string",
+                    "example": "authorized"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "confirmed by seller"
+                },
+                "to_status": {
+                    "type": "string",
+                    "example": "confirmed"
                 }
             }
         },
-        "dto.LoginResponseDTO": {
+        "dto.OrderResponseDTO": {
             "type": "object",
             "properties": {
-                "token": {
+                "ad_id": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "b72f25de-3ef1-4a36-9675-df31545fa08c"
+                },
+                "amount": {
+                    "type": "number",
+                    "example": 4500
+                },
+                "buyer_id": {
+                    "type": "string",
+                    "example": "a491c857-dbd0-4a4a-88dc-123456789abc"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-20T12:34:56Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5d0f5c7e-2b1a-4f43-9a3e-6f1c0b9e2d11"
+                },
+                "seller_id": {
+                    "type": "string",
+                    "example": "c1a2b3c4-d5e6-47f8-9a0b-1c2d3e4f5a6b"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "authorized"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-07-20T12:34:56Z"
+                }
+            }
+        },
+        "dto.OrdersResponseDTO": {
+            "type": "object",
+            "properties": {
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OrderResponseDTO"
+                    }
+                }
+            }
+        },
+        "dto.PrivacyRequestDTO": {
+            "type": "object",
+            "properties": {
+                "private": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
@@ -1034,6 +3626,10 @@ const docTemplate = `{
                 "password": {
                     "type": "string",
                     "example": "secret123"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+79991234567"
                 }
             }
         },
@@ -1055,6 +3651,26 @@ const docTemplate = `{
                 "name": {
                     "type": "string",
                     "example": "Иван"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+79991234567"
+                }
+            }
+        },
+        "dto.ReorderRequestDTO": {
+            "type": "object",
+            "properties": {
+                "image_ids": {
+                    "description": "ImageIds — все изображения объявления в нужном порядке, первое — обложка",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "f8c7e2a1-72e1-4c9e-bd84-7ae1b8fc4d4b",
+                        "0b6f1d52-3c1e-4a51-9d0e-5b1a7a3f2c11"
+                    ]
                 }
             }
         },
@@ -1076,6 +3692,95 @@ const docTemplate = `{
                 "imageUrl": {
                     "type": "string",
                     "example": "/static/upload/example.jpg"
+                },
+                "private": {
+                    "description": "Private — ссылки подписаны и действуют ограниченное время",
+                    "type": "boolean",
+                    "example": false
+                },
+                "variants": {
+                    "description": "Variants — URL копий: thumb (200px), medium (800px), original",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "medium": "/static/upload/example_medium.jpg",
+                        "original": "/static/upload/example.jpg",
+                        "thumb": "/static/upload/example_thumb.jpg"
+                    }
+                }
+            }
+        },
+        "dto.SellUnitsDTO": {
+            "type": "object",
+            "properties": {
+                "units": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.StockDTO": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "b72f25de-3ef1-4a36-9675-df31545fa08c"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "sold_out": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.StorageUsageDTO": {
+            "type": "object",
+            "properties": {
+                "images": {
+                    "description": "Images — сколько изображений во всех объявлениях пользователя",
+                    "type": "integer",
+                    "example": 3
+                },
+                "max_images_per_ad": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "quota_bytes": {
+                    "type": "integer",
+                    "example": 209715200
+                },
+                "used_bytes": {
+                    "type": "integer",
+                    "example": 1048576
+                }
+            }
+        },
+        "dto.TagDTO": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "name": {
+                    "type": "string",
+                    "example": "винтаж"
+                }
+            }
+        },
+        "dto.TagsResponseDTO": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TagDTO"
+                    }
                 }
             }
         }
@@ -1,12 +1,37 @@
 package apperr
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var ErrInvalidUUID = errors.New("uuid validation failed")
 
+var ErrInvalidQueryParam = errors.New("invalid query parameter")
+
+// FieldError — ошибка конкретного параметра запроса, errors.Is(err, ErrInvalidQueryParam) == true
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+func NewFieldError(field, reason string) *FieldError {
+	return &FieldError{Field: field, Reason: reason}
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+func (e *FieldError) Unwrap() error {
+	return ErrInvalidQueryParam
+}
+
 // ads err repo
 var ErrAdsNotFound = errors.New("ads not found")
 
+var ErrSitemapPageNotFound = errors.New("sitemap page not found")
+
 // ads err usecases
 var (
 	ErrTitleTooLong       = errors.New("title is too long")
@@ -15,6 +40,20 @@ var (
 	ErrInvalidLimit       = errors.New("limit is invalid")
 	ErrInvalidOffset      = errors.New("offset is invalid")
 	ErrForbidden          = errors.New("user is not owner")
+	ErrInvalidPublishAt   = errors.New("publish_at must be in the future")
+	ErrTooManyTags        = errors.New("too many tags")
+	ErrTagTooLong         = errors.New("tag is too long")
+	ErrInvalidQuantity    = errors.New("quantity is invalid")
+	ErrInvalidUnits       = errors.New("units must be positive")
+	ErrNotEnoughStock     = errors.New("not enough units in stock")
+	ErrAdSoldOut          = errors.New("ad is sold out")
+)
+
+// contact err
+var (
+	ErrInvalidPhone    = errors.New("phone is invalid")
+	ErrContactNotSet   = errors.New("seller has no contact phone")
+	ErrRevealRateLimit = errors.New("too many contact reveals")
 )
 
 //reg err
@@ -35,6 +74,36 @@ var (
 	ErrImgNotFound         = errors.New("image not found")
 	ErrAddNotFound         = errors.New("add  not found")
 	ErrUnsupportedFileType = errors.New("unsupported file type")
+	ErrInvalidImage        = errors.New("image cannot be decoded")
+	ErrBlobNotFound        = errors.New("file not found in storage")
+	ErrInvalidImageOrder   = errors.New("image ids must list every image of the ad exactly once")
+	ErrImageLimitReached   = errors.New("ad already has the maximum number of images")
+	ErrStorageQuota        = errors.New("storage quota exceeded")
+	ErrPrivateImages       = errors.New("images of this ad are visible only to logged-in users")
+	ErrSignatureRequired   = errors.New("signed url required")
+	ErrInvalidSignature    = errors.New("invalid or expired url signature")
+	ErrPrivateUnavailable  = errors.New("private images are unavailable while storage serves files from a public url")
+)
+
+// offers err
+var (
+	ErrOfferNotFound        = errors.New("offer not found")
+	ErrInvalidOfferAmount   = errors.New("offer amount is invalid")
+	ErrOfferMessageTooLong  = errors.New("offer message is too long")
+	ErrOfferOnOwnAd         = errors.New("cannot make an offer on your own ad")
+	ErrOfferExpired         = errors.New("offer expired")
+	ErrOfferTransition      = errors.New("offer cannot move to this state")
+	ErrOfferAlreadyAccepted = errors.New("ad already has an accepted offer")
+	ErrOfferAdClosed        = errors.New("ad no longer accepts offers")
+)
+
+// orders err
+var (
+	ErrOrderNotFound   = errors.New("order not found")
+	ErrOrderOnOwnAd    = errors.New("cannot order your own ad")
+	ErrOrderTransition = errors.New("order cannot move to this state")
+	ErrOrderAdBusy     = errors.New("ad already has an open order")
+	ErrPaymentFailed   = errors.New("payment failed")
 )
 
 // auth err
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// TTL — потокобезопасный кэш в памяти, записи живут ttl и удаляются при чтении или Set
+type TTL[K comparable, V any] struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	items map[K]entry[V]
+}
+
+func NewTTL[K comparable, V any](ttl time.Duration) *TTL[K, V] {
+	return &TTL[K, V]{
+		ttl:   ttl,
+		items: make(map[K]entry[V]),
+	}
+}
+
+func (c *TTL[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if time.Now().After(e.expiresAt) {
+		delete(c.items, key)
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+func (c *TTL[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	// чистим просроченное, чтобы кэш не рос бесконечно
+	for k, e := range c.items {
+		if now.After(e.expiresAt) {
+			delete(c.items, k)
+		}
+	}
+	c.items[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+func (c *TTL[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, key)
+}
+
+func (c *TTL[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.items)
+}
@@ -0,0 +1,213 @@
+package config
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	StorageLocal = "local"
+	StorageS3    = "s3"
+)
+
+// Config — настройки приложения из переменных окружения
+type Config struct {
+	// Storage — где хранить изображения: local или s3 (STORAGE_BACKEND)
+	Storage string
+	// UploadDir — каталог с загруженными изображениями для local (UPLOAD_DIR)
+	UploadDir string
+	S3        S3
+	Images    ImageLimits
+	GC        GC
+	ImageURLs ImageURLs
+}
+
+// ImageURLs — подписанные ссылки на закрытые изображения
+type ImageURLs struct {
+	// Secret — ключ HMAC (IMAGE_URL_SECRET). Если не задан, создаётся случайный
+	// при старте: ссылки перестанут работать после перезапуска и не подойдут
+	// другим экземплярам приложения
+	Secret    []byte
+	Generated bool
+	// TTL — срок жизни ссылки (IMAGE_URL_TTL, по умолчанию 1h)
+	TTL time.Duration
+}
+
+// GC — сборка файлов изображений, на которые никто не ссылается
+type GC struct {
+	// Interval — как часто запускать в сервере (GC_INTERVAL, по умолчанию 1h; 0 — не запускать)
+	Interval time.Duration
+	// Grace — файлы моложе не удаляются (GC_GRACE, по умолчанию 24h)
+	Grace time.Duration
+}
+
+// ImageLimits — ограничения на загрузку изображений; 0 — без ограничения
+type ImageLimits struct {
+	// MaxPerAd — изображений в одном объявлении (MAX_IMAGES_PER_AD, по умолчанию 10)
+	MaxPerAd int
+	// QuotaBytes — суммарный размер изображений пользователя (USER_STORAGE_QUOTA_MB, по умолчанию 200)
+	QuotaBytes int64
+}
+
+// S3 — настройки S3-совместимого хранилища (переменные S3_*)
+type S3 struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+	PublicURL string
+}
+
+func Load() (Config, error) {
+	cfg := Config{Storage: os.Getenv("STORAGE_BACKEND")}
+	if cfg.Storage == "" {
+		cfg.Storage = StorageLocal
+	}
+
+	switch cfg.Storage {
+	case StorageLocal:
+		dir, err := uploadDir()
+		if err != nil {
+			return Config{}, err
+		}
+		cfg.UploadDir = dir
+	case StorageS3:
+		s3, err := loadS3()
+		if err != nil {
+			return Config{}, err
+		}
+		cfg.S3 = s3
+	default:
+		return Config{}, fmt.Errorf("unknown STORAGE_BACKEND %q: must be local or s3", cfg.Storage)
+	}
+
+	limits, err := loadImageLimits()
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.Images = limits
+
+	gc, err := loadGC()
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.GC = gc
+
+	urls, err := loadImageURLs()
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.ImageURLs = urls
+	return cfg, nil
+}
+
+func loadImageURLs() (ImageURLs, error) {
+	ttl, err := durationEnv("IMAGE_URL_TTL", time.Hour)
+	if err != nil {
+		return ImageURLs{}, err
+	}
+	if ttl == 0 {
+		return ImageURLs{}, errors.New("invalid IMAGE_URL_TTL: must be positive")
+	}
+
+	urls := ImageURLs{Secret: []byte(os.Getenv("IMAGE_URL_SECRET")), TTL: ttl}
+	if len(urls.Secret) == 0 {
+		urls.Secret = make([]byte, 32)
+		if _, err := rand.Read(urls.Secret); err != nil {
+			return ImageURLs{}, err
+		}
+		urls.Generated = true
+	}
+	return urls, nil
+}
+
+func loadGC() (GC, error) {
+	interval, err := durationEnv("GC_INTERVAL", time.Hour)
+	if err != nil {
+		return GC{}, err
+	}
+	grace, err := durationEnv("GC_GRACE", 24*time.Hour)
+	if err != nil {
+		return GC{}, err
+	}
+	return GC{Interval: interval, Grace: grace}, nil
+}
+
+func durationEnv(name string, def time.Duration) (time.Duration, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s: must be a non-negative duration like 30m or 24h", name)
+	}
+	return d, nil
+}
+
+func loadImageLimits() (ImageLimits, error) {
+	maxPerAd, err := intEnv("MAX_IMAGES_PER_AD", 10)
+	if err != nil {
+		return ImageLimits{}, err
+	}
+	quotaMB, err := intEnv("USER_STORAGE_QUOTA_MB", 200)
+	if err != nil {
+		return ImageLimits{}, err
+	}
+	return ImageLimits{MaxPerAd: maxPerAd, QuotaBytes: int64(quotaMB) << 20}, nil
+}
+
+func intEnv(name string, def int) (int, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s: must be a non-negative integer", name)
+	}
+	return n, nil
+}
+
+func uploadDir() (string, error) {
+	dir := os.Getenv("UPLOAD_DIR")
+	if dir == "" {
+		// по умолчанию — static/upload рядом с каталогом запуска (build/ в Docker и make run)
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(wd, "..", "static", "upload")
+	}
+	return filepath.Abs(dir)
+}
+
+func loadS3() (S3, error) {
+	s3 := S3{
+		Endpoint:  os.Getenv("S3_ENDPOINT"),
+		Region:    os.Getenv("S3_REGION"),
+		Bucket:    os.Getenv("S3_BUCKET"),
+		AccessKey: os.Getenv("S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_SECRET_KEY"),
+		PublicURL: os.Getenv("S3_PUBLIC_URL"),
+	}
+	if s3.Endpoint == "" || s3.Bucket == "" {
+		return S3{}, errors.New("S3_ENDPOINT and S3_BUCKET are required for s3 storage")
+	}
+
+	if v := os.Getenv("S3_USE_SSL"); v != "" {
+		useSSL, err := strconv.ParseBool(v)
+		if err != nil {
+			return S3{}, fmt.Errorf("invalid S3_USE_SSL: %w", err)
+		}
+		s3.UseSSL = useSSL
+	}
+	return s3, nil
+}
@@ -9,4 +9,17 @@ type Ad struct {
 	Price       float64
 	CreatedAt   time.Time
 	AuthorId    string
+	PublishAt   time.Time // нулевое значение — объявление не было отложенным
+	Published   bool
+	Tags        []string
+	// Quantity — сколько одинаковых единиц товара осталось; 0 — всё продано
+	Quantity int
+	// ContactPhone — телефон для этого объявления; пустой — используется телефон из профиля автора
+	ContactPhone string
+}
+
+const MaxAdQuantity = 10000
+
+func (a Ad) SoldOut() bool {
+	return a.Quantity == 0
 }
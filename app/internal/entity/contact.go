@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	minPhoneDigits = 10
+	maxPhoneDigits = 15
+	// phoneVisibleDigits — сколько последних цифр остаётся видно в маскированном номере
+	phoneVisibleDigits = 2
+)
+
+// AdContact — контакт продавца по объявлению: номер из объявления, а если его нет — из профиля
+type AdContact struct {
+	AdId      string
+	AuthorId  string
+	Phone     string
+	Published bool
+}
+
+type RevealStats struct {
+	Total       int
+	UniqueUsers int
+	LastAt      time.Time
+}
+
+// NormalizePhone — оставляет только цифры с ведущим «+»: "+7 (999) 123-45-67" → "+79991234567".
+// ok == false, если номер не похож на телефон в международном формате. Номер без «+»
+// отклоняется: по "8 999 123-45-67" нельзя понять код страны, а "+8..." — другой номер.
+func NormalizePhone(phone string) (string, bool) {
+	phone = strings.TrimSpace(phone)
+	if phone == "" {
+		return "", true
+	}
+	if !strings.HasPrefix(phone, "+") {
+		return "", false
+	}
+
+	var digits strings.Builder
+	for i, ch := range phone {
+		switch {
+		case ch >= '0' && ch <= '9':
+			digits.WriteRune(ch)
+		case ch == '+' && i == 0:
+		case ch == ' ' || ch == '-' || ch == '(' || ch == ')':
+		default:
+			return "", false
+		}
+	}
+
+	n := digits.Len()
+	if n < minPhoneDigits || n > maxPhoneDigits {
+		return "", false
+	}
+	return "+" + digits.String(), true
+}
+
+// MaskPhone — скрывает номер, оставляя код страны и последние цифры: "+79991234567" → "+7*******67"
+func MaskPhone(phone string) string {
+	if len(phone) <= 2+phoneVisibleDigits {
+		return phone
+	}
+	return phone[:2] + strings.Repeat("*", len(phone)-2-phoneVisibleDigits) + phone[len(phone)-phoneVisibleDigits:]
+}
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// AdsFilter — параметры ленты. Нулевые значения означают «без ограничения».
+type AdsFilter struct {
+	Limit         int
+	Offset        int
+	Sort          []SortKey
+	PriceMin      float64
+	PriceMax      float64
+	PricePreset   string // имя диапазона из PricePresets, сужает PriceMin/PriceMax
+	AuthorId      string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	HasImages     *bool
+	Tags          []string
+	TagsMatchAll  bool   // true — у объявления должны быть все теги, false — хотя бы один
+	ViewerId      string // автор видит свои ещё не опубликованные объявления
+}
+
+// PriceRange — диапазон цен; нулевая граница — без ограничения
+type PriceRange struct {
+	Min float64
+	Max float64
+}
+
+// PricePresets — именованные диапазоны цен для фильтра ленты
+var PricePresets = map[string]PriceRange{
+	"budget":  {Max: 1000},
+	"mid":     {Min: 1000, Max: 10000},
+	"premium": {Min: 10000},
+}
@@ -2,9 +2,45 @@ package entity
 
 import "time"
 
+// Названия размеров изображения в AdImage.Variants
+const (
+	ImageThumb    = "thumb"
+	ImageMedium   = "medium"
+	ImageOriginal = "original"
+)
+
 type AdImage struct {
 	Id        string
 	AdId      string
 	ImageURL  string
 	CreatedAt time.Time
+	// Variants — URL копий по названию размера; у изображений, загруженных
+	// до появления копий, пустой
+	Variants map[string]string
+	// Position — место в галерее объявления, первое изображение — обложка
+	Position int
+	// Size — сколько байт занимают оригинал и копии, идёт в квоту автора
+	Size int64
+	// Hash — SHA-256 содержимого оригинала; по нему названы файлы, и одинаковые
+	// загрузки делят их между собой
+	Hash string
+	// Private — изображения объявления видны только авторизованным пользователям,
+	// по подписанным ссылкам с ограниченным сроком
+	Private bool
+}
+
+// StorageUsage — сколько места занимают изображения пользователя и сколько ему можно
+type StorageUsage struct {
+	UsedBytes      int64
+	QuotaBytes     int64
+	Images         int
+	MaxImagesPerAd int
+}
+
+// Variant — URL копии нужного размера, если её нет — оригинал
+func (i AdImage) Variant(name string) string {
+	if url, ok := i.Variants[name]; ok {
+		return url
+	}
+	return i.ImageURL
 }
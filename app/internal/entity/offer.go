@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+type OfferStatus string
+
+const (
+	OfferPending   OfferStatus = "pending"
+	OfferCountered OfferStatus = "countered"
+	OfferAccepted  OfferStatus = "accepted"
+	OfferRejected  OfferStatus = "rejected"
+	OfferExpired   OfferStatus = "expired"
+)
+
+type Offer struct {
+	Id             string
+	AdId           string
+	BuyerId        string
+	Amount         float64
+	Message        string
+	CounterAmount  float64
+	CounterMessage string
+	HideAd         bool
+	Status         OfferStatus
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ExpiresAt      time.Time
+}
+
+// IsOpen — предложение ещё ждёт ответа одной из сторон
+func (o Offer) IsOpen() bool {
+	return o.Status == OfferPending || o.Status == OfferCountered
+}
@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+type OrderStatus string
+
+const (
+	OrderCreated    OrderStatus = "created"
+	OrderAuthorized OrderStatus = "authorized"
+	OrderConfirmed  OrderStatus = "confirmed"
+	OrderCompleted  OrderStatus = "completed"
+	OrderCancelled  OrderStatus = "cancelled"
+	OrderFailed     OrderStatus = "failed"
+)
+
+type Order struct {
+	Id        string
+	AdId      string
+	BuyerId   string
+	SellerId  string
+	Amount    float64
+	Status    OrderStatus
+	PaymentId string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OrderEvent — запись журнала: кто и когда перевёл заказ из одного статуса в другой
+type OrderEvent struct {
+	Id         int64
+	OrderId    string
+	FromStatus OrderStatus
+	ToStatus   OrderStatus
+	ActorId    string
+	Note       string
+	CreatedAt  time.Time
+}
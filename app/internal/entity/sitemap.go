@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// SitemapURL — публичное объявление в sitemap, LastMod — последнее изменение, видимое на странице
+type SitemapURL struct {
+	AdId    string
+	LastMod time.Time
+}
+
+// SitemapPage — одна страница sitemap в индексе, Number начинается с 1
+type SitemapPage struct {
+	Number  int
+	LastMod time.Time
+}
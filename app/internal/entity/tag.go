@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	MaxTagsPerAd = 10
+	MaxTagLength = 30
+)
+
+type Tag struct {
+	Name  string
+	Count int
+}
+
+// NormalizeTag — приводит тег к каноническому виду: без «#», в нижнем регистре,
+// с одиночными пробелами между словами. "  #Винтаж   Торг " → "винтаж торг".
+func NormalizeTag(tag string) string {
+	tag = strings.TrimSpace(tag)
+	tag = strings.TrimLeft(tag, "#")
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
+
+// NormalizeTags — нормализует список и убирает пустые значения и повторы, сохраняя порядок
+func NormalizeTags(tags []string) []string {
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		res = append(res, tag)
+	}
+	return res
+}
+
+func TagTooLong(tag string) bool {
+	return utf8.RuneCountInString(tag) > MaxTagLength
+}
@@ -7,6 +7,7 @@ type User struct {
 	Username     string
 	Email        string
 	PasswordHash string
+	Phone        string
 	CreatedAt    time.Time
 }
 
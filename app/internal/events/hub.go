@@ -0,0 +1,131 @@
+package events
+
+import (
+	"market/app/internal/entity"
+	"sync"
+	"time"
+)
+
+type Type string
+
+const (
+	AdCreated    Type = "ad_created"
+	AdDeleted    Type = "ad_deleted"
+	ImageAdded   Type = "image_added"
+	ImageDeleted Type = "image_deleted"
+)
+
+// Event — событие ленты, которое рассылается всем подписчикам хаба
+type Event struct {
+	Id        uint64
+	Type      Type
+	AdId      string
+	UserId    string // автор объявления, используется для персональных каналов
+	Ad        *entity.Ad
+	Image     *entity.AdImage
+	CreatedAt time.Time
+}
+
+// Subscription — подписка на события. Канал C закрывается при отписке,
+// при закрытии хаба или если подписчик не успевает вычитывать события.
+type Subscription struct {
+	C      <-chan Event
+	ch     chan Event
+	userId string
+	hub    *Hub
+}
+
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// Hub — внутрипроцессный pub/sub с fan-out на всех подписчиков
+type Hub struct {
+	mu      sync.Mutex
+	subs    map[*Subscription]struct{}
+	buffer  int
+	seq     uint64
+	stopped bool
+}
+
+func NewHub(buffer int) *Hub {
+	if buffer <= 0 {
+		buffer = 16
+	}
+	return &Hub{
+		subs:   make(map[*Subscription]struct{}),
+		buffer: buffer,
+	}
+}
+
+// Subscribe — подписка на события. Если userId не пустой, подписчик получает
+// только события по объявлениям этого пользователя.
+func (h *Hub) Subscribe(userId string) *Subscription {
+	ch := make(chan Event, h.buffer)
+	sub := &Subscription{C: ch, ch: ch, userId: userId, hub: h}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.stopped {
+		close(ch)
+		return sub
+	}
+	h.subs[sub] = struct{}{}
+	return sub
+}
+
+// Publish — неблокирующая рассылка события. Подписчик с переполненным буфером
+// отключается, чтобы медленный клиент не тормозил остальных.
+func (h *Hub) Publish(e Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.stopped {
+		return
+	}
+
+	h.seq++
+	e.Id = h.seq
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now().UTC()
+	}
+
+	for sub := range h.subs {
+		if sub.userId != "" && sub.userId != e.UserId {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			delete(h.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Close — закрывает все подписки, дальнейшие события игнорируются
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.stopped {
+		return
+	}
+	h.stopped = true
+	for sub := range h.subs {
+		delete(h.subs, sub)
+		close(sub.ch)
+	}
+}
+
+func (h *Hub) unsubscribe(s *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subs[s]; !ok {
+		return
+	}
+	delete(h.subs, s)
+	close(s.ch)
+}
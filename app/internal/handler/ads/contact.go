@@ -0,0 +1,122 @@
+package ads
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"market/app/internal/apperr"
+	"market/app/internal/handler/ads/dto"
+	"market/app/internal/handler/ads/mapper"
+	"net/http"
+)
+
+// RevealContact godoc
+// @Summary      Показать телефон продавца
+// @Description  Возвращает полный номер продавца. Число показов ограничено для каждого пользователя, каждый показ учитывается в статистике владельца объявления.
+// @Tags         ads
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID объявления"
+// @Success      200  {object}  dto.ContactRevealDTO
+// @Failure      400  {object}  dto.ErrResponse400
+// @Failure      401  {object}  dto.ErrResponse401
+// @Failure      404  {object}  dto.ErrResponse404
+// @Failure      429  {object}  dto.ErrResponse429
+// @Failure      500  {object}  dto.ErrResponse500
+// @Router       /api/v1/ads/{id}/contact/reveal [post]
+func (a *AdsHandler) RevealContact(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, adId, ok := a.contactRequest(w, r)
+	if !ok {
+		return
+	}
+
+	phone, err := a.ads.RevealContact(adId, userId)
+	if err != nil {
+		a.writeContactErr(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dto.ContactRevealDTO{Phone: phone})
+}
+
+// GetContactStats godoc
+// @Summary      Статистика показов телефона
+// @Description  Сколько раз и сколько разных пользователей открывали телефон по объявлению. Только для владельца.
+// @Tags         ads
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID объявления"
+// @Success      200  {object}  dto.ContactStatsDTO
+// @Failure      400  {object}  dto.ErrResponse400
+// @Failure      401  {object}  dto.ErrResponse401
+// @Failure      403  {object}  dto.ErrResponse403
+// @Failure      404  {object}  dto.ErrResponse404
+// @Failure      500  {object}  dto.ErrResponse500
+// @Router       /api/v1/ads/{id}/contact/stats [get]
+func (a *AdsHandler) GetContactStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, adId, ok := a.contactRequest(w, r)
+	if !ok {
+		return
+	}
+
+	stats, err := a.ads.ContactStats(adId, userId)
+	if err != nil {
+		a.writeContactErr(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapper.ToContactStatsDTO(stats))
+}
+
+func (a *AdsHandler) contactRequest(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	userId, ok := r.Context().Value("user_id").(string)
+	if !ok || userId == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "unauthorized",
+		})
+		return "", "", false
+	}
+
+	adId := mux.Vars(r)["id"]
+	if err := uuid.Validate(adId); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusBadRequest,
+			Message: "id is invalid",
+		})
+		return "", "", false
+	}
+	return userId, adId, true
+}
+
+func (a *AdsHandler) writeContactErr(w http.ResponseWriter, err error) {
+	var code int
+	var message string
+	switch {
+	case errors.Is(err, apperr.ErrAdsNotFound):
+		code, message = http.StatusNotFound, "ads not found"
+	case errors.Is(err, apperr.ErrContactNotSet):
+		code, message = http.StatusNotFound, apperr.ErrContactNotSet.Error()
+	case errors.Is(err, apperr.ErrForbidden):
+		code, message = http.StatusForbidden, "you are not owner"
+	case errors.Is(err, apperr.ErrRevealRateLimit):
+		code, message = http.StatusTooManyRequests, err.Error()
+	default:
+		code, message = http.StatusInternalServerError, "internal server error"
+	}
+
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(dto.ErrResponse{
+		Code:    code,
+		Message: message,
+	})
+}
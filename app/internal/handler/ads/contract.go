@@ -9,5 +9,9 @@ type Ads interface {
 	Create(ad entity.Ad) (entity.Ad, error)
 	GetById(adId, userId string) (dto.AdDetailed, error)
 	Delete(adId, userId string) error
-	GetAll(userId string, limit, offset int, sortBy, order string, priceMin, priceMax float64) ([]dto.AdResponse, error)
+	GetAll(userId string, filter entity.AdsFilter) ([]dto.AdResponse, error)
+	Similar(adId, userId string, limit int) ([]dto.AdResponse, error)
+	SellUnits(adId, userId string, units int) (entity.Ad, error)
+	RevealContact(adId, userId string) (string, error)
+	ContactStats(adId, userId string) (entity.RevealStats, error)
 }
@@ -2,6 +2,7 @@ package ads
 
 import (
 	"encoding/json"
+	"errors"
 	"market/app/internal/apperr"
 	dto2 "market/app/internal/handler/ads/dto"
 	"market/app/internal/handler/ads/mapper"
@@ -11,7 +12,7 @@ import (
 
 // Create godoc
 // @Summary      Создать объявление
-// @Description  Создает новое объявление. Требует авторизации.
+// @Description  Создает новое объявление. Требует авторизации. С `publish_at` объявление остаётся скрытым от других пользователей до указанного времени. Теги нормализуются (нижний регистр, одиночные пробелы), не больше 10 тегов по 30 символов. `contact_phone` — телефон для этого объявления, без него используется телефон из профиля. `quantity` — число одинаковых единиц товара (по умолчанию 1, максимум 10000).
 // @Tags         ads
 // @Accept       json
 // @Produce      json
@@ -62,6 +63,19 @@ func (a *AdsHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	createdAd, err := a.ads.Create(adEntity)
 	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidPublishAt) ||
+			errors.Is(err, apperr.ErrTooManyTags) ||
+			errors.Is(err, apperr.ErrTagTooLong) ||
+			errors.Is(err, apperr.ErrInvalidPhone) ||
+			errors.Is(err, apperr.ErrInvalidQuantity) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(dto2.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			})
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(dto2.ErrResponse{
 			Code:    http.StatusInternalServerError,
 			Message: "internal server error",
@@ -69,14 +83,7 @@ func (a *AdsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := dto2.AdCreateRespDTO{
-		Id:          createdAd.Id,
-		Title:       createdAd.Title,
-		Description: createdAd.Description,
-		Price:       createdAd.Price,
-		CreatedAt:   createdAd.CreatedAt,
-		AuthorId:    createdAd.AuthorId,
-	}
+	resp := mapper.ToAdCreateRespDTO(createdAd)
 
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(resp)
@@ -3,21 +3,31 @@ package dto
 import "time"
 
 type AdsCreateDTO struct {
-	Title       string  `json:"title" example:"Велосипед"`
-	Description string  `json:"description" example:"Горный велосипед в хорошем состоянии"`
-	Price       float64 `json:"price" example:"5000"`
+	Title       string     `json:"title" example:"Велосипед"`
+	Description string     `json:"description" example:"Горный велосипед в хорошем состоянии"`
+	Price       float64    `json:"price" example:"5000"`
+	PublishAt   *time.Time `json:"publish_at,omitempty" example:"2025-07-21T08:00:00Z"`
+	Tags        []string   `json:"tags" example:"винтаж,торг"`
+	Phone       string     `json:"contact_phone,omitempty" example:"+79991234567"`
+	Quantity    *int       `json:"quantity,omitempty" example:"10"`
 }
 
 type AdResponseDTO struct {
-	Id          string    `json:"id" example:"b72f25de-3ef1-4a36-9675-df31545fa08c"`
-	Title       string    `json:"title" example:"Велосипед"`
-	Description string    `json:"description" example:"Горный велосипед в хорошем состоянии"`
-	Price       float64   `json:"price" example:"5000"`
-	Created     time.Time `json:"created_at" example:"2025-07-20T12:34:56Z"`
-	AuthorId    string    `json:"author_id" example:"a491c857-dbd0-4a4a-88dc-123456789abc"`
-	AuthorName  string    `json:"author_name" example:"Иван"`
-	IsOwner     bool      `json:"is_owner" example:"true"`
-	ImagesURl   []string  `json:"images" example:"['/static/upload/1.jpg','/static/upload/2.png']"`
+	Id          string     `json:"id" example:"b72f25de-3ef1-4a36-9675-df31545fa08c"`
+	Title       string     `json:"title" example:"Велосипед"`
+	Description string     `json:"description" example:"Горный велосипед в хорошем состоянии"`
+	Price       float64    `json:"price" example:"5000"`
+	Created     time.Time  `json:"created_at" example:"2025-07-20T12:34:56Z"`
+	AuthorId    string     `json:"author_id" example:"a491c857-dbd0-4a4a-88dc-123456789abc"`
+	AuthorName  string     `json:"author_name" example:"Иван"`
+	IsOwner     bool       `json:"is_owner" example:"true"`
+	ImagesURl   []string   `json:"images" example:"['/static/upload/1.jpg','/static/upload/2.png']"`
+	Cover       string     `json:"cover,omitempty" example:"/static/upload/1.jpg"`
+	Published   bool       `json:"published" example:"true"`
+	PublishAt   *time.Time `json:"publish_at,omitempty" example:"2025-07-21T08:00:00Z"`
+	Tags        []string   `json:"tags" example:"винтаж,торг"`
+	Quantity    int        `json:"quantity" example:"10"`
+	SoldOut     bool       `json:"sold_out" example:"false"`
 }
 
 type AdsResponseDTO struct {
@@ -25,22 +35,61 @@ type AdsResponseDTO struct {
 }
 
 type AdCreateRespDTO struct {
-	Id          string    `json:"id" example:"b72f25de-3ef1-4a36-9675-df31545fa08c"`
-	Title       string    `json:"title" example:"Велосипед"`
-	Description string    `json:"description" example:"Горный велосипед в хорошем состоянии"`
-	Price       float64   `json:"price" example:"5000"`
-	CreatedAt   time.Time `json:"created_at" example:"2025-07-20T12:34:56Z"`
-	AuthorId    string    `json:"author_id" example:"a491c857-dbd0-4a4a-88dc-123456789abc"`
+	Id          string     `json:"id" example:"b72f25de-3ef1-4a36-9675-df31545fa08c"`
+	Title       string     `json:"title" example:"Велосипед"`
+	Description string     `json:"description" example:"Горный велосипед в хорошем состоянии"`
+	Price       float64    `json:"price" example:"5000"`
+	CreatedAt   time.Time  `json:"created_at" example:"2025-07-20T12:34:56Z"`
+	AuthorId    string     `json:"author_id" example:"a491c857-dbd0-4a4a-88dc-123456789abc"`
+	Published   bool       `json:"published" example:"false"`
+	PublishAt   *time.Time `json:"publish_at,omitempty" example:"2025-07-21T08:00:00Z"`
+	Tags        []string   `json:"tags" example:"винтаж,торг"`
+	Quantity    int        `json:"quantity" example:"10"`
+	SoldOut     bool       `json:"sold_out" example:"false"`
 }
 
 type AdDetailedResponseDTO struct {
-	Id          string    `json:"id" example:"b72f25de-3ef1-4a36-9675-df31545fa08c"`
-	Title       string    `json:"title" example:"Велосипед"`
-	Description string    `json:"description" example:"Горный велосипед в хорошем состоянии"`
-	Price       float64   `json:"price" example:"5000"`
-	CreatedAt   time.Time `json:"created_at" example:"2025-07-20T12:34:56Z"`
-	AuthorId    string    `json:"author_id" example:"a491c857-dbd0-4a4a-88dc-123456789abc"`
-	AuthorName  string    `json:"author_name" example:"Иван"`
-	Images      []string  `json:"images" example:"['/static/upload/1.jpg','/static/upload/2.png']"`
-	IsOwner     bool      `json:"is_owner" example:"true"`
+	Id          string             `json:"id" example:"b72f25de-3ef1-4a36-9675-df31545fa08c"`
+	Title       string             `json:"title" example:"Велосипед"`
+	Description string             `json:"description" example:"Горный велосипед в хорошем состоянии"`
+	Price       float64            `json:"price" example:"5000"`
+	CreatedAt   time.Time          `json:"created_at" example:"2025-07-20T12:34:56Z"`
+	AuthorId    string             `json:"author_id" example:"a491c857-dbd0-4a4a-88dc-123456789abc"`
+	AuthorName  string             `json:"author_name" example:"Иван"`
+	Images      []string           `json:"images" example:"['/static/upload/1.jpg','/static/upload/2.png']"`
+	Variants    []ImageVariantsDTO `json:"variants"`
+	IsOwner     bool               `json:"is_owner" example:"true"`
+	Published   bool               `json:"published" example:"true"`
+	PublishAt   *time.Time         `json:"publish_at,omitempty" example:"2025-07-21T08:00:00Z"`
+	Tags        []string           `json:"tags" example:"винтаж,торг"`
+	Phone       string             `json:"contact_phone,omitempty" example:"+7*******67"`
+	Quantity    int                `json:"quantity" example:"10"`
+	SoldOut     bool               `json:"sold_out" example:"false"`
+}
+
+// ImageVariantsDTO — изображение объявления и URL его копий: thumb (200px), medium (800px), original.
+// В AdDetailedResponseDTO.Variants — в том же порядке, что images.
+type ImageVariantsDTO struct {
+	Id       string            `json:"id" example:"3d6f8a10-5b2c-4e7d-9f1a-2c4b6d8e0f13"`
+	Variants map[string]string `json:"variants" example:"thumb:/static/upload/example_thumb.jpg,medium:/static/upload/example_medium.jpg,original:/static/upload/example.jpg"`
+}
+
+type SellUnitsDTO struct {
+	Units int `json:"units" example:"1"`
+}
+
+type ContactRevealDTO struct {
+	Phone string `json:"phone" example:"+79991234567"`
+}
+
+type ContactStatsDTO struct {
+	Total       int        `json:"total" example:"12"`
+	UniqueUsers int        `json:"unique_users" example:"9"`
+	LastAt      *time.Time `json:"last_at,omitempty" example:"2025-07-20T12:34:56Z"`
+}
+
+type StockDTO struct {
+	Id       string `json:"id" example:"b72f25de-3ef1-4a36-9675-df31545fa08c"`
+	Quantity int    `json:"quantity" example:"9"`
+	SoldOut  bool   `json:"sold_out" example:"false"`
 }
@@ -45,3 +45,13 @@ type ErrResponse403 struct {
 	Message string `json:"message" example:"you are not owner"`
 	Code    int    `json:"code" example:"403"`
 }
+
+type ErrResponse429 struct {
+	Message string `json:"message" example:"too many contact reveals: retry in 42m0s"`
+	Code    int    `json:"code" example:"429"`
+}
+
+type ErrResponse409 struct {
+	Message string `json:"message" example:"not enough units in stock"`
+	Code    int    `json:"code" example:"409"`
+}
@@ -0,0 +1,148 @@
+package ads
+
+import (
+	"github.com/google/uuid"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseFilter — разбирает параметры ленты. Любое нераспознанное значение — ошибка
+// с именем параметра (apperr.FieldError), а не молчаливый ноль.
+func ParseFilter(q url.Values) (entity.AdsFilter, error) {
+	var filter entity.AdsFilter
+	var err error
+
+	if filter.Limit, err = parseInt(q, "limit"); err != nil {
+		return entity.AdsFilter{}, err
+	}
+	if filter.Offset, err = parseInt(q, "offset"); err != nil {
+		return entity.AdsFilter{}, err
+	}
+	if filter.PriceMin, err = parsePrice(q, "min"); err != nil {
+		return entity.AdsFilter{}, err
+	}
+	if filter.PriceMax, err = parsePrice(q, "max"); err != nil {
+		return entity.AdsFilter{}, err
+	}
+	filter.PricePreset = q.Get("price")
+	if filter.CreatedAfter, err = parseTime(q, "created_after"); err != nil {
+		return entity.AdsFilter{}, err
+	}
+	if filter.CreatedBefore, err = parseTime(q, "created_before"); err != nil {
+		return entity.AdsFilter{}, err
+	}
+
+	if v := q.Get("author_id"); v != "" {
+		if err := uuid.Validate(v); err != nil {
+			return entity.AdsFilter{}, apperr.NewFieldError("author_id", "must be a UUID")
+		}
+		filter.AuthorId = v
+	}
+
+	if v := q.Get("has_images"); v != "" {
+		hasImages, err := strconv.ParseBool(v)
+		if err != nil {
+			return entity.AdsFilter{}, apperr.NewFieldError("has_images", "must be true or false")
+		}
+		filter.HasImages = &hasImages
+	}
+
+	if v := q.Get("tags"); v != "" {
+		filter.Tags = strings.Split(v, ",")
+	}
+	switch q.Get("tags_mode") {
+	case "", "any":
+	case "all":
+		filter.TagsMatchAll = true
+	default:
+		return entity.AdsFilter{}, apperr.NewFieldError("tags_mode", "must be any or all")
+	}
+
+	if filter.Sort, err = parseSort(q.Get("sort"), q.Get("order")); err != nil {
+		return entity.AdsFilter{}, err
+	}
+
+	return filter, nil
+}
+
+func parseInt(q url.Values, field string) (int, error) {
+	v := q.Get(field)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, apperr.NewFieldError(field, "must be an integer")
+	}
+	return n, nil
+}
+
+func parsePrice(q url.Values, field string) (float64, error) {
+	v := q.Get(field)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, apperr.NewFieldError(field, "must be a number")
+	}
+	return n, nil
+}
+
+// parseTime — RFC 3339 или просто дата (YYYY-MM-DD, полночь UTC)
+func parseTime(q url.Values, field string) (time.Time, error) {
+	v := q.Get(field)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t, nil
+	}
+	return time.Time{}, apperr.NewFieldError(field, "must be RFC 3339 timestamp or YYYY-MM-DD date")
+}
+
+// parseSort — sort=price,-created_at: ключи через запятую, «-» — по убыванию, «+» — по возрастанию.
+// Для ключей без знака направление берётся из order (по умолчанию desc).
+func parseSort(sort, order string) ([]entity.SortKey, error) {
+	defaultDesc := true
+	switch order {
+	case "", "desc":
+	case "asc":
+		defaultDesc = false
+	default:
+		return nil, apperr.NewFieldError("order", "must be asc or desc")
+	}
+
+	if sort == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(sort, ",")
+	keys := make([]entity.SortKey, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		key := entity.SortKey{Desc: defaultDesc}
+		switch {
+		case strings.HasPrefix(part, "-"):
+			key.Desc = true
+			part = part[1:]
+		case strings.HasPrefix(part, "+"):
+			key.Desc = false
+			part = part[1:]
+		}
+		if part == "" {
+			return nil, apperr.NewFieldError("sort", "empty sort key")
+		}
+		key.Field = part
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
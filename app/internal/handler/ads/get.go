@@ -10,21 +10,27 @@ import (
 	"market/app/internal/handler/ads/dto"
 	"market/app/internal/handler/ads/mapper"
 	"net/http"
-	"strconv"
 )
 
 // GetAllAds godoc
 // @Summary      Получить все объявления
-// @Description  Возвращает список всех объявлений. Не требует авторизации, но если токен передан — отмечает ваши объявления как `is_owner=true`.
+// @Description  Возвращает список всех объявлений. Не требует авторизации, но если токен передан — отмечает ваши объявления как `is_owner=true`. Некорректный параметр — 400 с его именем в сообщении.
 // @Tags         ads
 // @Accept       json
 // @Produce      json
-// @Param        limit    query     int     false  "Ограничение по количеству"
-// @Param        offset   query     int     false  "Смещение"
-// @Param        sort     query     string  false  "Поле для сортировки"
-// @Param        order    query     string  false  "asc или desc"
-// @Param        min      query     number  false  "Минимальная цена"
-// @Param        max      query     number  false  "Максимальная цена"
+// @Param        limit           query     int     false  "Ограничение по количеству (1–100, по умолчанию 10)"
+// @Param        offset          query     int     false  "Смещение"
+// @Param        sort            query     string  false  "Ключи сортировки через запятую: created_at, price, title; префикс - по убыванию, + по возрастанию"
+// @Param        order           query     string  false  "asc или desc — направление для ключей без префикса"
+// @Param        min             query     number  false  "Минимальная цена"
+// @Param        max             query     number  false  "Максимальная цена"
+// @Param        price           query     string  false  "Диапазон цен: budget (до 1000), mid (1000–10000) или premium (от 10000); min и max сужают его"  Enums(budget, mid, premium)
+// @Param        author_id       query     string  false  "ID автора"
+// @Param        created_after   query     string  false  "Созданы не раньше (RFC 3339 или YYYY-MM-DD)"
+// @Param        created_before  query     string  false  "Созданы раньше (RFC 3339 или YYYY-MM-DD)"
+// @Param        has_images      query     bool    false  "Только с изображениями / только без"
+// @Param        tags            query     string  false  "Теги через запятую"
+// @Param        tags_mode       query     string  false  "any (хотя бы один тег, по умолчанию) или all (все теги)"
 // @Success      200  {object}  dto.AdsResponseDTO
 // @Failure      400  {object}  dto.ErrResponse400
 // @Failure      404  {object}  dto.ErrResponse404ArrExample
@@ -39,14 +45,7 @@ func (a *AdsHandler) GetAllAds(w http.ResponseWriter, r *http.Request) {
 		userID = id
 	}
 
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-	sort := r.URL.Query().Get("sort")
-	order := r.URL.Query().Get("order")
-	minPrice, _ := strconv.ParseFloat(r.URL.Query().Get("min"), 64)
-	maxPrice, _ := strconv.ParseFloat(r.URL.Query().Get("max"), 64)
-
-	err := a.validateQueryParams(limit, offset, minPrice, maxPrice)
+	filter, err := ParseFilter(r.URL.Query())
 	if err != nil {
 
 		w.WriteHeader(http.StatusBadRequest)
@@ -57,9 +56,17 @@ func (a *AdsHandler) GetAllAds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := a.ads.GetAll(userID, limit, offset, sort, order, minPrice, maxPrice)
+	res, err := a.ads.GetAll(userID, filter)
 	if err != nil {
 		log.Println(err)
+		if errors.Is(err, apperr.ErrInvalidQueryParam) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
 		if errors.Is(err, apperr.ErrAdsNotFound) {
 			w.WriteHeader(http.StatusNotFound)
 			json.NewEncoder(w).Encode(dto.ErrResponseNotFound{
@@ -82,27 +89,6 @@ func (a *AdsHandler) GetAllAds(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-func (a *AdsHandler) validateQueryParams(limit, offset int, priceMin, priceMax float64) error {
-
-	if limit < 0 {
-		return apperr.ErrInvalidLimit
-	}
-
-	if offset < 0 {
-		return apperr.ErrInvalidOffset
-	}
-
-	if priceMin > 0 && priceMax > 0 && priceMax < priceMin {
-		return apperr.ErrInvalidPrice
-	}
-
-	if priceMin < 0 || priceMax < 0 {
-		return apperr.ErrInvalidPrice
-	}
-
-	return nil
-}
-
 // GetAdByID godoc
 // @Summary      Получить объявление по ID
 // @Description  Возвращает детальное объявление. Можно передать токен, чтобы узнать `is_owner`.
@@ -3,16 +3,60 @@ package mapper
 import (
 	"market/app/internal/entity"
 	"market/app/internal/handler/ads/dto"
+	imgmapper "market/app/internal/handler/image/mapper"
 	usecases "market/app/internal/usecases/ads/dto"
+	"time"
 )
 
 func ToAdEntity(dto dto.AdsCreateDTO, authorID string) entity.Ad {
-	return entity.Ad{
-		Title:       dto.Title,
-		Description: dto.Description,
-		Price:       dto.Price,
-		AuthorId:    authorID,
+	ad := entity.Ad{
+		Title:        dto.Title,
+		Description:  dto.Description,
+		Price:        dto.Price,
+		AuthorId:     authorID,
+		Tags:         dto.Tags,
+		ContactPhone: dto.Phone,
+		Quantity:     1,
 	}
+	if dto.Quantity != nil {
+		ad.Quantity = *dto.Quantity
+	}
+	if dto.PublishAt != nil {
+		ad.PublishAt = *dto.PublishAt
+	}
+	return ad
+}
+
+func ToAdCreateRespDTO(ad entity.Ad) dto.AdCreateRespDTO {
+	return dto.AdCreateRespDTO{
+		Id:          ad.Id,
+		Title:       ad.Title,
+		Description: ad.Description,
+		Price:       ad.Price,
+		CreatedAt:   ad.CreatedAt,
+		AuthorId:    ad.AuthorId,
+		Published:   ad.Published,
+		PublishAt:   optionalTime(ad.PublishAt),
+		Tags:        tagsOrEmpty(ad.Tags),
+		Quantity:    ad.Quantity,
+		SoldOut:     ad.SoldOut(),
+	}
+}
+
+// tagsOrEmpty — в JSON всегда массив, а не null
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return make([]string, 0)
+	}
+	return tags
+}
+
+// optionalTime — nil для нулевого времени, чтобы поле не попадало в JSON
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
 }
 
 func DtoUsecaseResponseToAdResponse(data usecases.AdResponse) dto.AdResponseDTO {
@@ -22,13 +66,28 @@ func DtoUsecaseResponseToAdResponse(data usecases.AdResponse) dto.AdResponseDTO
 		Title:       data.Title,
 		Description: data.Description,
 		Price:       data.Price,
+		Created:     data.CreatedAt,
 		AuthorName:  data.Author,
 		AuthorId:    data.AuthorID,
 		IsOwner:     data.IsOwner,
 		ImagesURl:   data.Images,
+		Cover:       cover(data.Images),
+		Published:   data.Published,
+		PublishAt:   optionalTime(data.PublishAt),
+		Tags:        tagsOrEmpty(data.Tags),
+		Quantity:    data.Quantity,
+		SoldOut:     data.Quantity == 0,
 	}
 }
 
+// cover — обложка объявления: изображения уже отсортированы по position
+func cover(images []string) string {
+	if len(images) == 0 {
+		return ""
+	}
+	return images[0]
+}
+
 func DtoUsecaseGetToDtoHandler(data []usecases.AdResponse) dto.AdsResponseDTO {
 	res := dto.AdsResponseDTO{}
 	res.Ads = make([]dto.AdResponseDTO, 0, len(data))
@@ -40,8 +99,10 @@ func DtoUsecaseGetToDtoHandler(data []usecases.AdResponse) dto.AdsResponseDTO {
 
 func ToAdDetailedResponseDTO(data usecases.AdDetailed) dto.AdDetailedResponseDTO {
 	images := make([]string, 0, len(data.Images))
+	variants := make([]dto.ImageVariantsDTO, 0, len(data.Images))
 	for _, img := range data.Images {
 		images = append(images, img.ImageURL)
+		variants = append(variants, dto.ImageVariantsDTO{Id: img.Id, Variants: imgmapper.Variants(img)})
 	}
 
 	return dto.AdDetailedResponseDTO{
@@ -53,6 +114,29 @@ func ToAdDetailedResponseDTO(data usecases.AdDetailed) dto.AdDetailedResponseDTO
 		AuthorId:    data.Ad.AuthorId,
 		AuthorName:  data.Author,
 		Images:      images,
+		Variants:    variants,
 		IsOwner:     data.IsOwner,
+		Published:   data.Ad.Published,
+		PublishAt:   optionalTime(data.Ad.PublishAt),
+		Tags:        tagsOrEmpty(data.Ad.Tags),
+		Phone:       data.Phone,
+		Quantity:    data.Ad.Quantity,
+		SoldOut:     data.Ad.SoldOut(),
+	}
+}
+
+func ToContactStatsDTO(stats entity.RevealStats) dto.ContactStatsDTO {
+	return dto.ContactStatsDTO{
+		Total:       stats.Total,
+		UniqueUsers: stats.UniqueUsers,
+		LastAt:      optionalTime(stats.LastAt),
+	}
+}
+
+func ToStockDTO(ad entity.Ad) dto.StockDTO {
+	return dto.StockDTO{
+		Id:       ad.Id,
+		Quantity: ad.Quantity,
+		SoldOut:  ad.SoldOut(),
 	}
 }
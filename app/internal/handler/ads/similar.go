@@ -0,0 +1,87 @@
+package ads
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"market/app/internal/apperr"
+	"market/app/internal/handler/ads/dto"
+	"market/app/internal/handler/ads/mapper"
+	"net/http"
+	"strconv"
+)
+
+// GetSimilar godoc
+// @Summary      Похожие объявления
+// @Description  Объявления других авторов, похожие по заголовку и описанию, с близкой ценой; свежие выше. Результат кэшируется на несколько минут.
+// @Tags         ads
+// @Produce      json
+// @Param        id     path      string  true   "ID объявления"
+// @Param        limit  query     int     false  "Количество (по умолчанию 10, максимум 50)"
+// @Success      200  {object}  dto.AdsResponseDTO
+// @Failure      400  {object}  dto.ErrResponse400
+// @Failure      404  {object}  dto.ErrResponse404
+// @Failure      500  {object}  dto.ErrResponse500
+// @Router       /api/v1/ads/{id}/similar [get]
+func (a *AdsHandler) GetSimilar(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var userId string
+	if id, ok := r.Context().Value("user_id").(string); ok {
+		userId = id
+	}
+
+	adId := mux.Vars(r)["id"]
+	if err := uuid.Validate(adId); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusBadRequest,
+			Message: "id is invalid",
+		})
+		return
+	}
+
+	var limit int
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: apperr.ErrInvalidLimit.Error(),
+			})
+			return
+		}
+		limit = n
+	}
+
+	res, err := a.ads.Similar(adId, userId, limit)
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidLimit) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: apperr.ErrInvalidLimit.Error(),
+			})
+			return
+		}
+		if errors.Is(err, apperr.ErrAdsNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Code:    http.StatusNotFound,
+				Message: "ads not found",
+			})
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapper.DtoUsecaseGetToDtoHandler(res))
+}
@@ -0,0 +1,91 @@
+package ads
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"market/app/internal/apperr"
+	"market/app/internal/handler/ads/dto"
+	"market/app/internal/handler/ads/mapper"
+	"net/http"
+)
+
+// SellUnits godoc
+// @Summary      Отметить проданные единицы
+// @Description  Продавец уменьшает остаток товара на units. Списание атомарное: при параллельных запросах остаток не уходит в минус, а при нуле объявление становится распроданным (`sold_out=true`). Требует авторизации.
+// @Tags         ads
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path  string            true  "ID объявления"
+// @Param        body  body  dto.SellUnitsDTO  true  "Сколько единиц продано"
+// @Success      200  {object}  dto.StockDTO
+// @Failure      400  {object}  dto.ErrResponse400
+// @Failure      401  {object}  dto.ErrResponse401
+// @Failure      403  {object}  dto.ErrResponse403
+// @Failure      404  {object}  dto.ErrResponse404
+// @Failure      409  {object}  dto.ErrResponse409
+// @Failure      500  {object}  dto.ErrResponse500
+// @Router       /api/v1/ads/{id}/sold [post]
+func (a *AdsHandler) SellUnits(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, ok := r.Context().Value("user_id").(string)
+	if !ok || userId == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	adId := mux.Vars(r)["id"]
+	if err := uuid.Validate(adId); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusBadRequest,
+			Message: "id is invalid",
+		})
+		return
+	}
+
+	var req dto.SellUnitsDTO
+	defer r.Body.Close()
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid JSON",
+		})
+		return
+	}
+
+	ad, err := a.ads.SellUnits(adId, userId, req.Units)
+	if err != nil {
+		var code int
+		var message string
+		switch {
+		case errors.Is(err, apperr.ErrInvalidUnits):
+			code, message = http.StatusBadRequest, apperr.ErrInvalidUnits.Error()
+		case errors.Is(err, apperr.ErrAdsNotFound):
+			code, message = http.StatusNotFound, "ads not found"
+		case errors.Is(err, apperr.ErrForbidden):
+			code, message = http.StatusForbidden, "you are not owner"
+		case errors.Is(err, apperr.ErrNotEnoughStock):
+			code, message = http.StatusConflict, apperr.ErrNotEnoughStock.Error()
+		default:
+			code, message = http.StatusInternalServerError, "internal server error"
+		}
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    code,
+			Message: message,
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapper.ToStockDTO(ad))
+}
@@ -0,0 +1,10 @@
+package feeds
+
+import (
+	"market/app/internal/entity"
+	"market/app/internal/usecases/ads/dto"
+)
+
+type Ads interface {
+	GetAll(userId string, filter entity.AdsFilter) ([]dto.AdResponse, error)
+}
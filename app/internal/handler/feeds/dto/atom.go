@@ -0,0 +1,45 @@
+package dto
+
+import "encoding/xml"
+
+// AtomFeed — лента в формате Atom 1.0 (RFC 4287)
+type AtomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Id      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []AtomLink  `xml:"link"`
+	Author  AtomPerson  `xml:"author"`
+	Entries []AtomEntry `xml:"entry"`
+}
+
+type AtomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+type AtomPerson struct {
+	Name string `xml:"name"`
+}
+
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type AtomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type AtomEntry struct {
+	Id         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Links      []AtomLink     `xml:"link"`
+	Author     AtomPerson     `xml:"author"`
+	Categories []AtomCategory `xml:"category"`
+	Summary    AtomText       `xml:"summary"`
+}
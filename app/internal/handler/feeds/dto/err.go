@@ -0,0 +1,16 @@
+package dto
+
+type ErrResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type ErrFeed400 struct {
+	Message string `json:"message" example:"invalid limit: must be an integer"`
+	Code    int    `json:"code" example:"400"`
+}
+
+type ErrFeed500 struct {
+	Message string `json:"message" example:"internal server error"`
+	Code    int    `json:"code" example:"500"`
+}
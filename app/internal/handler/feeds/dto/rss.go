@@ -0,0 +1,50 @@
+package dto
+
+import "encoding/xml"
+
+// RSS — лента в формате RSS 2.0
+type RSS struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel RSSChannel `xml:"channel"`
+}
+
+type RSSChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      RSSSelf   `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []RSSItem `xml:"item"`
+}
+
+// RSSSelf — ссылка на саму ленту, рекомендуется валидатором RSS
+type RSSSelf struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type RSSGuid struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RSSEnclosure — в RSS 2.0 у элемента может быть только одно вложение,
+// length обязателен и равен 0, если размер неизвестен
+type RSSEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type RSSItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description"`
+	Guid        RSSGuid       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *RSSEnclosure `xml:"enclosure"`
+}
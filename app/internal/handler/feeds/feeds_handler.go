@@ -0,0 +1,162 @@
+package feeds
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"market/app/internal/apperr"
+	"market/app/internal/handler/ads"
+	"market/app/internal/handler/feeds/dto"
+	"market/app/internal/handler/feeds/mapper"
+	usecases "market/app/internal/usecases/ads/dto"
+	"market/app/internal/utils"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type FeedsHandler struct {
+	ads Ads
+}
+
+func NewFeedsHandler(ads Ads) *FeedsHandler {
+	return &FeedsHandler{ads}
+}
+
+// Atom godoc
+// @Summary      Лента объявлений в Atom
+// @Description  Atom 1.0 с последними объявлениями. Принимает те же параметры, что и GET /api/v1/ads, изображения — ссылки rel="enclosure". Поддерживает If-None-Match и If-Modified-Since.
+// @Tags         feeds
+// @Produce      xml
+// @Param        limit           query     int     false  "Ограничение по количеству (1–100, по умолчанию 10)"
+// @Param        offset          query     int     false  "Смещение"
+// @Param        sort            query     string  false  "Ключи сортировки через запятую: created_at, price, title"
+// @Param        min             query     number  false  "Минимальная цена"
+// @Param        max             query     number  false  "Максимальная цена"
+// @Param        author_id       query     string  false  "ID автора"
+// @Param        has_images      query     bool    false  "Только с изображениями / только без"
+// @Param        tags            query     string  false  "Теги через запятую"
+// @Param        tags_mode       query     string  false  "any или all"
+// @Success      200  {string}  string  "Atom 1.0"
+// @Success      304  "Лента не изменилась"
+// @Failure      400  {object}  dto.ErrFeed400
+// @Failure      500  {object}  dto.ErrFeed500
+// @Router       /api/v1/feeds/ads.atom [get]
+func (f *FeedsHandler) Atom(w http.ResponseWriter, r *http.Request) {
+	f.serve(w, r, "application/atom+xml; charset=utf-8", func(feed mapper.Feed, res []usecases.AdResponse) any {
+		return mapper.ToAtom(feed, res)
+	})
+}
+
+// RSS godoc
+// @Summary      Лента объявлений в RSS
+// @Description  RSS 2.0 с последними объявлениями. Принимает те же параметры, что и GET /api/v1/ads, первое изображение — enclosure. Поддерживает If-None-Match и If-Modified-Since.
+// @Tags         feeds
+// @Produce      xml
+// @Param        limit           query     int     false  "Ограничение по количеству (1–100, по умолчанию 10)"
+// @Param        offset          query     int     false  "Смещение"
+// @Param        sort            query     string  false  "Ключи сортировки через запятую: created_at, price, title"
+// @Param        min             query     number  false  "Минимальная цена"
+// @Param        max             query     number  false  "Максимальная цена"
+// @Param        author_id       query     string  false  "ID автора"
+// @Param        has_images      query     bool    false  "Только с изображениями / только без"
+// @Param        tags            query     string  false  "Теги через запятую"
+// @Param        tags_mode       query     string  false  "any или all"
+// @Success      200  {string}  string  "RSS 2.0"
+// @Success      304  "Лента не изменилась"
+// @Failure      400  {object}  dto.ErrFeed400
+// @Failure      500  {object}  dto.ErrFeed500
+// @Router       /api/v1/feeds/ads.rss [get]
+func (f *FeedsHandler) RSS(w http.ResponseWriter, r *http.Request) {
+	f.serve(w, r, "application/rss+xml; charset=utf-8", func(feed mapper.Feed, res []usecases.AdResponse) any {
+		return mapper.ToRSS(feed, res)
+	})
+}
+
+// serve — общая часть лент: фильтры как у ленты объявлений, без учёта токена,
+// чтобы ответ был одинаковым для всех читателей и хорошо кэшировался
+func (f *FeedsHandler) serve(w http.ResponseWriter, r *http.Request, contentType string, build func(mapper.Feed, []usecases.AdResponse) any) {
+	filter, err := ads.ParseFilter(r.URL.Query())
+	if err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := f.ads.GetAll("", filter)
+	if err != nil && !errors.Is(err, apperr.ErrAdsNotFound) {
+		if errors.Is(err, apperr.ErrInvalidQueryParam) {
+			writeErr(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeErr(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	base := utils.BaseURL(r)
+	feed := mapper.Feed{
+		BaseURL: base,
+		SelfURL: base + r.URL.RequestURI(),
+	}
+	for _, ad := range res {
+		if updated := mapper.Updated(ad); updated.After(feed.Updated) {
+			feed.Updated = updated
+		}
+	}
+
+	var body bytes.Buffer
+	body.WriteString(xml.Header)
+	if err := xml.NewEncoder(&body).Encode(build(feed, res)); err != nil {
+		writeErr(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	sum := sha256.Sum256(body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	// HTTP-даты с точностью до секунды
+	lastModified := feed.Updated.UTC().Truncate(time.Second)
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+// notModified — If-None-Match важнее If-Modified-Since (RFC 9110, 13.2.2)
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		t, err := http.ParseTime(ims)
+		return err == nil && !lastModified.After(t)
+	}
+	return false
+}
+
+func writeErr(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(dto.ErrResponse{
+		Code:    code,
+		Message: message,
+	})
+}
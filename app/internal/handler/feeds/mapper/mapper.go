@@ -0,0 +1,124 @@
+package mapper
+
+import (
+	"market/app/internal/handler/feeds/dto"
+	usecases "market/app/internal/usecases/ads/dto"
+	"market/app/internal/utils"
+	"mime"
+	"path"
+	"strconv"
+	"time"
+)
+
+const feedTitle = "Market — объявления"
+
+// Feed — общие данные ленты: BaseURL — схема и хост для абсолютных ссылок,
+// SelfURL — адрес самой ленты вместе с параметрами поиска
+type Feed struct {
+	BaseURL string
+	SelfURL string
+	Updated time.Time
+}
+
+// Updated — время появления объявления в ленте: для отложенных — время публикации
+func Updated(ad usecases.AdResponse) time.Time {
+	if !ad.PublishAt.IsZero() {
+		return ad.PublishAt
+	}
+	return ad.CreatedAt
+}
+
+func ToAtom(feed Feed, ads []usecases.AdResponse) dto.AtomFeed {
+	res := dto.AtomFeed{
+		Id:      feed.SelfURL,
+		Title:   feedTitle,
+		Updated: feed.Updated.UTC().Format(time.RFC3339),
+		Links: []dto.AtomLink{
+			{Href: feed.SelfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: feed.BaseURL + "/ads", Rel: "alternate", Type: "text/html"},
+		},
+		Author:  dto.AtomPerson{Name: "Market"},
+		Entries: make([]dto.AtomEntry, 0, len(ads)),
+	}
+
+	for _, ad := range ads {
+		updated := Updated(ad).UTC().Format(time.RFC3339)
+
+		links := []dto.AtomLink{{Href: adURL(feed, ad), Rel: "alternate", Type: "text/html"}}
+		for _, img := range ad.Images {
+			links = append(links, dto.AtomLink{
+				Href: utils.AbsoluteURL(feed.BaseURL, img),
+				Rel:  "enclosure",
+				Type: imageType(img),
+			})
+		}
+
+		categories := make([]dto.AtomCategory, 0, len(ad.Tags))
+		for _, tag := range ad.Tags {
+			categories = append(categories, dto.AtomCategory{Term: tag})
+		}
+
+		res.Entries = append(res.Entries, dto.AtomEntry{
+			Id:         "urn:uuid:" + ad.Id,
+			Title:      ad.Title,
+			Updated:    updated,
+			Published:  updated,
+			Links:      links,
+			Author:     dto.AtomPerson{Name: ad.Author},
+			Categories: categories,
+			Summary:    dto.AtomText{Type: "text", Body: summary(ad)},
+		})
+	}
+	return res
+}
+
+func ToRSS(feed Feed, ads []usecases.AdResponse) dto.RSS {
+	res := dto.RSS{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: dto.RSSChannel{
+			Title:       feedTitle,
+			Link:        feed.BaseURL + "/ads",
+			Description: "Новые объявления по заданным фильтрам",
+			AtomLink:    dto.RSSSelf{Href: feed.SelfURL, Rel: "self", Type: "application/rss+xml"},
+			Items:       make([]dto.RSSItem, 0, len(ads)),
+		},
+	}
+	if !feed.Updated.IsZero() {
+		res.Channel.LastBuildDate = feed.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, ad := range ads {
+		item := dto.RSSItem{
+			Title:       ad.Title,
+			Link:        adURL(feed, ad),
+			Description: summary(ad),
+			Guid:        dto.RSSGuid{IsPermaLink: false, Value: ad.Id},
+			PubDate:     Updated(ad).UTC().Format(time.RFC1123Z),
+			Categories:  ad.Tags,
+		}
+		if len(ad.Images) > 0 {
+			item.Enclosure = &dto.RSSEnclosure{
+				URL:  utils.AbsoluteURL(feed.BaseURL, ad.Images[0]),
+				Type: imageType(ad.Images[0]),
+			}
+		}
+		res.Channel.Items = append(res.Channel.Items, item)
+	}
+	return res
+}
+
+func adURL(feed Feed, ad usecases.AdResponse) string {
+	return feed.BaseURL + "/ads/" + ad.Id
+}
+
+func summary(ad usecases.AdResponse) string {
+	return "Цена: " + strconv.FormatFloat(ad.Price, 'f', -1, 64) + " ₽\n\n" + ad.Description
+}
+
+func imageType(url string) string {
+	if t := mime.TypeByExtension(path.Ext(url)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
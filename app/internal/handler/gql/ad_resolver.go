@@ -0,0 +1,151 @@
+package gql
+
+import (
+	"context"
+	"market/app/internal/entity"
+	"market/app/internal/usecases/ads/dto"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// adResolver — объявление. Для одиночного запроса автор, изображения и теги
+// уже загружены (detailed), для ленты догружаются пачками через loaders.
+type adResolver struct {
+	ad       entity.Ad
+	userId   string
+	detailed *dto.AdDetailed
+}
+
+func (a *adResolver) ID() graphql.ID {
+	return graphql.ID(a.ad.Id)
+}
+
+func (a *adResolver) Title() string {
+	return a.ad.Title
+}
+
+func (a *adResolver) Description() string {
+	return a.ad.Description
+}
+
+func (a *adResolver) Price() float64 {
+	return a.ad.Price
+}
+
+func (a *adResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: a.ad.CreatedAt}
+}
+
+func (a *adResolver) Published() bool {
+	return a.ad.Published
+}
+
+func (a *adResolver) PublishAt() *graphql.Time {
+	if a.ad.PublishAt.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: a.ad.PublishAt}
+}
+
+func (a *adResolver) Quantity() int32 {
+	return int32(a.ad.Quantity)
+}
+
+func (a *adResolver) SoldOut() bool {
+	return a.ad.SoldOut()
+}
+
+func (a *adResolver) IsOwner() bool {
+	return a.userId != "" && a.ad.AuthorId == a.userId
+}
+
+func (a *adResolver) Author(ctx context.Context) (*userResolver, error) {
+	if a.detailed != nil {
+		return &userResolver{entity.User{Id: a.ad.AuthorId, Username: a.detailed.Author}}, nil
+	}
+
+	user, err := loadersFrom(ctx).authors.Load(ctx, a.ad.AuthorId)
+	if err != nil {
+		return nil, toGQLError(err)
+	}
+	user.Id = a.ad.AuthorId
+	return &userResolver{user}, nil
+}
+
+func (a *adResolver) Images(ctx context.Context) ([]*imageResolver, error) {
+	var images []entity.AdImage
+	if a.detailed != nil {
+		images = a.detailed.Images
+	} else {
+		var err error
+		images, err = loadersFrom(ctx).images.Load(ctx, a.ad.Id)
+		if err != nil {
+			return nil, toGQLError(err)
+		}
+	}
+
+	res := make([]*imageResolver, 0, len(images))
+	for _, img := range images {
+		res = append(res, &imageResolver{img})
+	}
+	return res, nil
+}
+
+func (a *adResolver) Tags(ctx context.Context) ([]string, error) {
+	if a.detailed != nil || a.ad.Tags != nil {
+		return tagsOrEmpty(a.ad.Tags), nil
+	}
+
+	tags, err := loadersFrom(ctx).tags.Load(ctx, a.ad.Id)
+	if err != nil {
+		return nil, toGQLError(err)
+	}
+	return tagsOrEmpty(tags), nil
+}
+
+type userResolver struct {
+	user entity.User
+}
+
+func (u *userResolver) ID() graphql.ID {
+	return graphql.ID(u.user.Id)
+}
+
+func (u *userResolver) Name() string {
+	return u.user.Username
+}
+
+type imageResolver struct {
+	img entity.AdImage
+}
+
+func (i *imageResolver) ID() graphql.ID {
+	return graphql.ID(i.img.Id)
+}
+
+func (i *imageResolver) AdID() graphql.ID {
+	return graphql.ID(i.img.AdId)
+}
+
+func (i *imageResolver) URL() string {
+	return i.img.ImageURL
+}
+
+func (i *imageResolver) ThumbURL() string {
+	return i.img.Variant(entity.ImageThumb)
+}
+
+func (i *imageResolver) MediumURL() string {
+	return i.img.Variant(entity.ImageMedium)
+}
+
+func (i *imageResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: i.img.CreatedAt}
+}
+
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return make([]string, 0)
+	}
+	return tags
+}
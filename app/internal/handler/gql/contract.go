@@ -0,0 +1,20 @@
+package gql
+
+import (
+	"market/app/internal/entity"
+	"market/app/internal/usecases/ads/dto"
+)
+
+type Ads interface {
+	Feed(userId string, filter entity.AdsFilter) ([]entity.Ad, error)
+	GetById(adId, userId string) (dto.AdDetailed, error)
+	Create(ad entity.Ad) (entity.Ad, error)
+	Delete(adId, userId string) error
+	Authors(ids []string) (map[string]entity.User, error)
+	TagsByAds(adIds []string) (map[string][]string, error)
+}
+
+type Img interface {
+	AddImage(adId, userId string, data []byte, ext string) (entity.AdImage, error)
+	ImagesByAds(adIds []string, viewerId string) (map[string][]entity.AdImage, error)
+}
@@ -0,0 +1,73 @@
+package gql
+
+import (
+	"errors"
+	"log"
+	"market/app/internal/apperr"
+)
+
+const (
+	codeUnauthenticated = "UNAUTHENTICATED"
+	codeForbidden       = "FORBIDDEN"
+	codeNotFound        = "NOT_FOUND"
+	codeBadUserInput    = "BAD_USER_INPUT"
+	codeLimitExceeded   = "LIMIT_EXCEEDED"
+	codeInternal        = "INTERNAL_SERVER_ERROR"
+)
+
+// gqlError — ошибка резолвера с кодом в extensions, как принято в GraphQL-клиентах
+type gqlError struct {
+	message string
+	code    string
+}
+
+func newGQLError(message, code string) *gqlError {
+	return &gqlError{message: message, code: code}
+}
+
+func (e *gqlError) Error() string {
+	return e.message
+}
+
+func (e *gqlError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+var (
+	errUnauthenticated = newGQLError("unauthorized", codeUnauthenticated)
+	errInvalidAdId     = newGQLError("invalid ad id", codeBadUserInput)
+)
+
+var badUserInput = []error{
+	apperr.ErrInvalidQueryParam,
+	apperr.ErrTitleTooLong,
+	apperr.ErrDescriptionTooLong,
+	apperr.ErrInvalidPrice,
+	apperr.ErrInvalidPublishAt,
+	apperr.ErrTooManyTags,
+	apperr.ErrTagTooLong,
+	apperr.ErrInvalidPhone,
+	apperr.ErrInvalidQuantity,
+	apperr.ErrUnsupportedFileType,
+	apperr.ErrInvalidImage,
+}
+
+// toGQLError — apperr в ошибку с кодом; текст внутренних ошибок клиенту не отдаётся
+func toGQLError(err error) error {
+	switch {
+	case errors.Is(err, apperr.ErrAdsNotFound), errors.Is(err, apperr.ErrAddNotFound):
+		return newGQLError("ad not found", codeNotFound)
+	case errors.Is(err, apperr.ErrForbidden):
+		return newGQLError("you are not owner", codeForbidden)
+	case errors.Is(err, apperr.ErrImageLimitReached), errors.Is(err, apperr.ErrStorageQuota):
+		return newGQLError(err.Error(), codeLimitExceeded)
+	}
+	for _, target := range badUserInput {
+		if errors.Is(err, target) {
+			return newGQLError(err.Error(), codeBadUserInput)
+		}
+	}
+
+	log.Println("graphql:", err)
+	return newGQLError("internal server error", codeInternal)
+}
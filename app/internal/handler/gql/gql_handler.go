@@ -0,0 +1,77 @@
+package gql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxParallelism — не меньше максимального размера страницы ленты, чтобы поля всех
+// объявлений резолвились одновременно и загрузчики собирали их в одну пачку
+const maxParallelism = 100
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type GraphQLHandler struct {
+	ads    Ads
+	img    Img
+	schema *graphql.Schema
+}
+
+func NewGraphQLHandler(ads Ads, img Img) *GraphQLHandler {
+	schema := graphql.MustParseSchema(schemaSDL, &Resolver{ads: ads, img: img},
+		graphql.UseFieldResolvers(),
+		graphql.MaxParallelism(maxParallelism),
+	)
+	return &GraphQLHandler{ads: ads, img: img, schema: schema}
+}
+
+// ServeHTTP godoc
+// @Summary      GraphQL
+// @Description  Запросы ads/ad и мутации createAd/deleteAd/uploadImage, схема — internal/handler/gql/schema.graphql. Токен передаётся так же, как в REST (Bearer). Файлы загружаются по спецификации GraphQL multipart request.
+// @Tags         graphql
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  map[string]interface{}
+// @Router       /graphql [post]
+func (g *GraphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		if err := parseMultipart(r, &req); err != nil {
+			writeErr(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErr(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	ctx := withLoaders(r.Context(), g.ads, g.img)
+	res := g.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+func writeErr(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]string{{"message": message}},
+	})
+}
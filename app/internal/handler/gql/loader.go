@@ -0,0 +1,97 @@
+package gql
+
+import (
+	"context"
+	"market/app/internal/entity"
+	"sync"
+	"time"
+)
+
+// loaderWait — сколько ждать остальные ключи пачки. Резолверы полей списка
+// выполняются параллельно, поэтому за это время успевают прийти все ключи страницы.
+const loaderWait = 2 * time.Millisecond
+
+// Loader — батчинг в стиле dataloader: ключи, запрошенные почти одновременно,
+// загружаются одним вызовом fetch. Результаты кэшируются на время запроса.
+type Loader[K comparable, V any] struct {
+	fetch func(keys []K) (map[K]V, error)
+
+	mu    sync.Mutex
+	batch *batch[K, V]
+	cache map[K]*batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	keys []K
+	done chan struct{}
+	res  map[K]V
+	err  error
+}
+
+func NewLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch: fetch,
+		cache: make(map[K]*batch[K, V]),
+	}
+}
+
+// Load — значение по ключу; отсутствующий в ответе fetch ключ даёт нулевое значение
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	b, ok := l.cache[key]
+	if !ok {
+		if l.batch == nil {
+			l.batch = &batch[K, V]{done: make(chan struct{})}
+			time.AfterFunc(loaderWait, l.run)
+		}
+		b = l.batch
+		b.keys = append(b.keys, key)
+		l.cache[key] = b
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+		return b.res[key], b.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *Loader[K, V]) run() {
+	l.mu.Lock()
+	b := l.batch
+	l.batch = nil
+	l.mu.Unlock()
+
+	b.res, b.err = l.fetch(b.keys)
+	close(b.done)
+}
+
+// loaders — загрузчики одного запроса, живут в контексте
+type loaders struct {
+	authors *Loader[string, entity.User]
+	images  *Loader[string, []entity.AdImage]
+	tags    *Loader[string, []string]
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, ads Ads, img Img) context.Context {
+	// ссылки на закрытые изображения зависят от того, кто спрашивает
+	viewerId := userIdFrom(ctx)
+	images := func(adIds []string) (map[string][]entity.AdImage, error) {
+		return img.ImagesByAds(adIds, viewerId)
+	}
+
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		authors: NewLoader(ads.Authors),
+		images:  NewLoader(images),
+		tags:    NewLoader(ads.TagsByAds),
+	})
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,223 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"io"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// Resolver — корневой резолвер схемы schema.graphql
+type Resolver struct {
+	ads Ads
+	img Img
+}
+
+type adsFilterInput struct {
+	Limit         *int32
+	Offset        *int32
+	Sort          *[]sortKeyInput
+	PriceMin      *float64
+	PriceMax      *float64
+	PricePreset   *string
+	AuthorId      *graphql.ID
+	CreatedAfter  *graphql.Time
+	CreatedBefore *graphql.Time
+	HasImages     *bool
+	Tags          *[]string
+	TagsMode      *string
+}
+
+type sortKeyInput struct {
+	Field string
+	Desc  *bool
+}
+
+type createAdInput struct {
+	Title        string
+	Description  string
+	Price        float64
+	PublishAt    *graphql.Time
+	Tags         *[]string
+	Quantity     *int32
+	ContactPhone *string
+}
+
+func (r *Resolver) Ads(ctx context.Context, args struct{ Filter *adsFilterInput }) ([]*adResolver, error) {
+	userId := userIdFrom(ctx)
+
+	filter, err := toFilter(args.Filter)
+	if err != nil {
+		return nil, toGQLError(err)
+	}
+
+	ads, err := r.ads.Feed(userId, filter)
+	if err != nil && !errors.Is(err, apperr.ErrAdsNotFound) {
+		return nil, toGQLError(err)
+	}
+
+	res := make([]*adResolver, 0, len(ads))
+	for _, ad := range ads {
+		res = append(res, &adResolver{ad: ad, userId: userId})
+	}
+	return res, nil
+}
+
+func (r *Resolver) Ad(ctx context.Context, args struct{ Id graphql.ID }) (*adResolver, error) {
+	userId := userIdFrom(ctx)
+	// объявления с некорректным id не существует
+	if err := uuid.Validate(string(args.Id)); err != nil {
+		return nil, nil
+	}
+
+	res, err := r.ads.GetById(string(args.Id), userId)
+	if err != nil {
+		if errors.Is(err, apperr.ErrAdsNotFound) {
+			return nil, nil
+		}
+		return nil, toGQLError(err)
+	}
+	return &adResolver{ad: res.Ad, userId: userId, detailed: &res}, nil
+}
+
+func (r *Resolver) CreateAd(ctx context.Context, args struct{ Input createAdInput }) (*adResolver, error) {
+	userId := userIdFrom(ctx)
+	if userId == "" {
+		return nil, errUnauthenticated
+	}
+
+	ad := entity.Ad{
+		Title:       args.Input.Title,
+		Description: args.Input.Description,
+		Price:       args.Input.Price,
+		AuthorId:    userId,
+		Quantity:    1,
+	}
+	if args.Input.PublishAt != nil {
+		ad.PublishAt = args.Input.PublishAt.Time
+	}
+	if args.Input.Tags != nil {
+		ad.Tags = *args.Input.Tags
+	}
+	if args.Input.Quantity != nil {
+		ad.Quantity = int(*args.Input.Quantity)
+	}
+	if args.Input.ContactPhone != nil {
+		ad.ContactPhone = *args.Input.ContactPhone
+	}
+
+	created, err := r.ads.Create(ad)
+	if err != nil {
+		return nil, toGQLError(err)
+	}
+	return &adResolver{ad: created, userId: userId}, nil
+}
+
+func (r *Resolver) DeleteAd(ctx context.Context, args struct{ Id graphql.ID }) (bool, error) {
+	userId := userIdFrom(ctx)
+	if userId == "" {
+		return false, errUnauthenticated
+	}
+	if err := uuid.Validate(string(args.Id)); err != nil {
+		return false, errInvalidAdId
+	}
+
+	if err := r.ads.Delete(string(args.Id), userId); err != nil {
+		return false, toGQLError(err)
+	}
+	return true, nil
+}
+
+func (r *Resolver) UploadImage(ctx context.Context, args struct {
+	AdId graphql.ID
+	File Upload
+}) (*imageResolver, error) {
+	userId := userIdFrom(ctx)
+	if userId == "" {
+		return nil, errUnauthenticated
+	}
+	defer args.File.File.Close()
+	if err := uuid.Validate(string(args.AdId)); err != nil {
+		return nil, errInvalidAdId
+	}
+
+	data, err := io.ReadAll(io.LimitReader(args.File.File, maxUploadSize+1))
+	if err != nil {
+		return nil, toGQLError(err)
+	}
+	if len(data) > maxUploadSize {
+		return nil, newGQLError("file is too large", codeBadUserInput)
+	}
+
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return nil, toGQLError(apperr.ErrUnsupportedFileType)
+	}
+
+	img, err := r.img.AddImage(string(args.AdId), userId, data, contentType)
+	if err != nil {
+		return nil, toGQLError(err)
+	}
+	return &imageResolver{img}, nil
+}
+
+// toFilter — те же проверки, что у ParseFilter в REST; диапазоны проверяет usecase
+func toFilter(in *adsFilterInput) (entity.AdsFilter, error) {
+	var f entity.AdsFilter
+	if in == nil {
+		return f, nil
+	}
+
+	if in.Limit != nil {
+		f.Limit = int(*in.Limit)
+	}
+	if in.Offset != nil {
+		f.Offset = int(*in.Offset)
+	}
+	if in.Sort != nil {
+		for _, key := range *in.Sort {
+			f.Sort = append(f.Sort, entity.SortKey{
+				Field: strings.ToLower(key.Field),
+				Desc:  key.Desc != nil && *key.Desc,
+			})
+		}
+	}
+	if in.PriceMin != nil {
+		f.PriceMin = *in.PriceMin
+	}
+	if in.PriceMax != nil {
+		f.PriceMax = *in.PriceMax
+	}
+	if in.PricePreset != nil {
+		f.PricePreset = strings.ToLower(*in.PricePreset)
+	}
+	if in.AuthorId != nil {
+		if err := uuid.Validate(string(*in.AuthorId)); err != nil {
+			return entity.AdsFilter{}, apperr.NewFieldError("authorId", "must be a UUID")
+		}
+		f.AuthorId = string(*in.AuthorId)
+	}
+	if in.CreatedAfter != nil {
+		f.CreatedAfter = in.CreatedAfter.Time
+	}
+	if in.CreatedBefore != nil {
+		f.CreatedBefore = in.CreatedBefore.Time
+	}
+	f.HasImages = in.HasImages
+	if in.Tags != nil {
+		f.Tags = *in.Tags
+	}
+	f.TagsMatchAll = in.TagsMode != nil && *in.TagsMode == "ALL"
+	return f, nil
+}
+
+func userIdFrom(ctx context.Context) string {
+	userId, _ := ctx.Value("user_id").(string)
+	return userId
+}
@@ -0,0 +1,58 @@
+package gql
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// nopFile — multipart.File поверх байтов
+type nopFile struct {
+	*bytes.Reader
+}
+
+func (nopFile) Close() error { return nil }
+
+func errorCode(t *testing.T, err error) string {
+	t.Helper()
+	var gqlErr *gqlError
+	if !errors.As(err, &gqlErr) {
+		t.Fatalf("error = %v, want a GraphQL error with a code", err)
+	}
+	return gqlErr.code
+}
+
+// TestMalformedIdsAreUserErrors — некорректный id не доходит до usecase: у резолвера
+// нет ни ads, ни img, и обращение к ним упало бы
+func TestMalformedIdsAreUserErrors(t *testing.T) {
+	r := &Resolver{}
+	ctx := context.WithValue(context.Background(), "user_id", "1f9a7b52-3c1e-4b8d-a0f4-5e2d9c6b7a30")
+	const badId = graphql.ID("not-a-uuid")
+
+	ad, err := r.Ad(ctx, struct{ Id graphql.ID }{badId})
+	if ad != nil || err != nil {
+		t.Errorf("Ad() = %v, %v, want null without an error", ad, err)
+	}
+
+	_, err = r.DeleteAd(ctx, struct{ Id graphql.ID }{badId})
+	if code := errorCode(t, err); code != codeBadUserInput {
+		t.Errorf("DeleteAd() code = %s, want %s", code, codeBadUserInput)
+	}
+
+	_, err = r.UploadImage(ctx, struct {
+		AdId graphql.ID
+		File Upload
+	}{badId, Upload{File: nopFile{bytes.NewReader(nil)}}})
+	if code := errorCode(t, err); code != codeBadUserInput {
+		t.Errorf("UploadImage() code = %s, want %s", code, codeBadUserInput)
+	}
+
+	authorId := badId
+	_, err = r.Ads(ctx, struct{ Filter *adsFilterInput }{&adsFilterInput{AuthorId: &authorId}})
+	if code := errorCode(t, err); code != codeBadUserInput {
+		t.Errorf("Ads() with bad authorId code = %s, want %s", code, codeBadUserInput)
+	}
+}
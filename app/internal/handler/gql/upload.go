@@ -0,0 +1,66 @@
+package gql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+const maxUploadSize = 10 << 20
+
+// Upload — скаляр для файлов из multipart-запроса
+// (https://github.com/jaydenseric/graphql-multipart-request-spec)
+type Upload struct {
+	File     multipart.File
+	Filename string
+}
+
+func (Upload) ImplementsGraphQLType(name string) bool {
+	return name == "Upload"
+}
+
+func (u *Upload) UnmarshalGraphQL(input interface{}) error {
+	if v, ok := input.(*Upload); ok {
+		*u = *v
+		return nil
+	}
+	return errors.New("file must be sent as a multipart upload")
+}
+
+// parseMultipart — разбирает multipart-запрос: поле operations — обычный JSON-запрос,
+// map — какие файлы подставить в какие переменные, например {"0": ["variables.file"]}
+func parseMultipart(r *http.Request, req *request) error {
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		return fmt.Errorf("invalid multipart body: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(r.FormValue("operations")), req); err != nil {
+		return fmt.Errorf("invalid operations: %w", err)
+	}
+
+	var files map[string][]string
+	if err := json.Unmarshal([]byte(r.FormValue("map")), &files); err != nil {
+		return fmt.Errorf("invalid map: %w", err)
+	}
+
+	if req.Variables == nil {
+		req.Variables = make(map[string]interface{})
+	}
+	for field, paths := range files {
+		file, header, err := r.FormFile(field)
+		if err != nil {
+			return fmt.Errorf("file %q is missing: %w", field, err)
+		}
+		for _, path := range paths {
+			name, ok := strings.CutPrefix(path, "variables.")
+			if !ok || strings.Contains(name, ".") {
+				return fmt.Errorf("unsupported map path %q", path)
+			}
+			req.Variables[name] = &Upload{File: file, Filename: header.Filename}
+		}
+	}
+	return nil
+}
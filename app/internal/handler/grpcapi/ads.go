@@ -0,0 +1,96 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"market/app/internal/handler/grpcapi/pb"
+
+	"github.com/google/uuid"
+)
+
+type adsServer struct {
+	pb.UnimplementedAdsServiceServer
+	ads Ads
+}
+
+func (s *adsServer) ListAds(ctx context.Context, req *pb.ListAdsRequest) (*pb.ListAdsResponse, error) {
+	userId := userIdFrom(ctx)
+
+	filter, err := toFilter(req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	ads, err := s.ads.GetAll(userId, filter)
+	// пустая лента в gRPC — не ошибка, а пустой список
+	if err != nil && !errors.Is(err, apperr.ErrAdsNotFound) {
+		return nil, toStatus(err)
+	}
+
+	res := &pb.ListAdsResponse{Ads: make([]*pb.Ad, 0, len(ads))}
+	for _, ad := range ads {
+		res.Ads = append(res.Ads, toAd(ad))
+	}
+	return res, nil
+}
+
+func (s *adsServer) GetAd(ctx context.Context, req *pb.GetAdRequest) (*pb.Ad, error) {
+	if err := uuid.Validate(req.GetId()); err != nil {
+		return nil, toStatus(apperr.ErrInvalidUUID)
+	}
+
+	res, err := s.ads.GetById(req.GetId(), userIdFrom(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toAdDetailed(res), nil
+}
+
+func (s *adsServer) CreateAd(ctx context.Context, req *pb.CreateAdRequest) (*pb.Ad, error) {
+	userId := userIdFrom(ctx)
+	if userId == "" {
+		return nil, errUnauthenticated
+	}
+
+	ad := entity.Ad{
+		Title:        req.GetTitle(),
+		Description:  req.GetDescription(),
+		Price:        req.GetPrice(),
+		AuthorId:     userId,
+		Tags:         req.GetTags(),
+		Quantity:     1,
+		ContactPhone: req.GetContactPhone(),
+	}
+	if req.PublishAt != nil {
+		ad.PublishAt = req.GetPublishAt().AsTime()
+	}
+	if req.Quantity != nil {
+		ad.Quantity = int(req.GetQuantity())
+	}
+
+	created, err := s.ads.Create(ad)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := toAdEntity(created)
+	res.IsOwner = true
+	return res, nil
+}
+
+func (s *adsServer) DeleteAd(ctx context.Context, req *pb.DeleteAdRequest) (*pb.DeleteAdResponse, error) {
+	userId := userIdFrom(ctx)
+	if userId == "" {
+		return nil, errUnauthenticated
+	}
+	if err := uuid.Validate(req.GetId()); err != nil {
+		return nil, toStatus(apperr.ErrInvalidUUID)
+	}
+
+	if err := s.ads.Delete(req.GetId(), userId); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DeleteAdResponse{}, nil
+}
@@ -0,0 +1,63 @@
+package grpcapi
+
+import (
+	"context"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"market/app/internal/handler/grpcapi/pb"
+)
+
+type authServer struct {
+	pb.UnimplementedAuthServiceServer
+	auth Auth
+	reg  Registry
+}
+
+func (s *authServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.User, error) {
+	switch {
+	case req.GetName() == "":
+		return nil, toStatus(apperr.ErrNameIsRequired)
+	case req.GetEmail() == "":
+		return nil, toStatus(apperr.ErrEmailRequired)
+	case req.GetPassword() == "":
+		return nil, toStatus(apperr.ErrPassRequired)
+	}
+
+	user, err := s.reg.Registration(entity.User{
+		Username:     req.GetName(),
+		Email:        req.GetEmail(),
+		PasswordHash: req.GetPassword(),
+		Phone:        req.GetPhone(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toUser(user), nil
+}
+
+func (s *authServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	switch {
+	case req.GetEmail() == "":
+		return nil, toStatus(apperr.ErrEmailRequired)
+	case req.GetPassword() == "":
+		return nil, toStatus(apperr.ErrPassRequired)
+	}
+
+	token, err := s.auth.Login(req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.LoginResponse{Token: token}, nil
+}
+
+func (s *authServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	userId := userIdFrom(ctx)
+	if userId == "" {
+		return nil, errUnauthenticated
+	}
+
+	if err := s.auth.Logout(userId); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.LogoutResponse{}, nil
+}
@@ -0,0 +1,29 @@
+package grpcapi
+
+import (
+	"market/app/internal/entity"
+	"market/app/internal/usecases/ads/dto"
+)
+
+type Ads interface {
+	GetAll(userId string, filter entity.AdsFilter) ([]dto.AdResponse, error)
+	GetById(adId, userId string) (dto.AdDetailed, error)
+	Create(ad entity.Ad) (entity.Ad, error)
+	Delete(adId, userId string) error
+}
+
+type Img interface {
+	AddImage(adId, userId string, data []byte, ext string) (entity.AdImage, error)
+	GetImages(adId, viewerId string) ([]entity.AdImage, error)
+	GetImageById(id, viewerId string) (entity.AdImage, error)
+}
+
+type Auth interface {
+	Login(email, password string) (string, error)
+	Logout(userID string) error
+	ValidateSession(token string) (string, error)
+}
+
+type Registry interface {
+	Registration(user entity.User) (entity.User, error)
+}
@@ -0,0 +1,68 @@
+package grpcapi
+
+import (
+	"errors"
+	"log"
+	"market/app/internal/apperr"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errUnauthenticated = status.Error(codes.Unauthenticated, "unauthorized")
+
+var invalidArgument = []error{
+	apperr.ErrInvalidUUID,
+	apperr.ErrInvalidQueryParam,
+	apperr.ErrTitleTooLong,
+	apperr.ErrDescriptionTooLong,
+	apperr.ErrInvalidPrice,
+	apperr.ErrInvalidPublishAt,
+	apperr.ErrTooManyTags,
+	apperr.ErrTagTooLong,
+	apperr.ErrInvalidPhone,
+	apperr.ErrInvalidQuantity,
+	apperr.ErrUnsupportedFileType,
+	apperr.ErrInvalidImage,
+	apperr.ErrInvalidEmail,
+	apperr.ErrInvalidLenPassword,
+	apperr.ErrNonUpperCharPass,
+	apperr.ErrNonLowerCharPass,
+	apperr.ErrNonDigitPass,
+	apperr.ErrNonSpecialPass,
+	apperr.ErrEmailRequired,
+	apperr.ErrPassRequired,
+	apperr.ErrNameIsRequired,
+}
+
+// toStatus — apperr в статус gRPC; текст внутренних ошибок клиенту не отдаётся
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, apperr.ErrAdsNotFound), errors.Is(err, apperr.ErrAddNotFound):
+		return status.Error(codes.NotFound, "ad not found")
+	case errors.Is(err, apperr.ErrImgNotFound):
+		return status.Error(codes.NotFound, "image not found")
+	case errors.Is(err, apperr.ErrUserNotFound):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, apperr.ErrForbidden):
+		return status.Error(codes.PermissionDenied, "you are not owner")
+	case errors.Is(err, apperr.ErrIncorrectPassword):
+		return status.Error(codes.Unauthenticated, "incorrect password")
+	case errors.Is(err, apperr.ErrSessionExpired):
+		return status.Error(codes.Unauthenticated, "session expired")
+	case errors.Is(err, apperr.ErrPrivateImages):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, apperr.ErrImageLimitReached), errors.Is(err, apperr.ErrStorageQuota):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, apperr.ErrEmailAlreadyExists):
+		return status.Error(codes.AlreadyExists, "email already exists")
+	}
+	for _, target := range invalidArgument {
+		if errors.Is(err, target) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	log.Println("grpc:", err)
+	return status.Error(codes.Internal, "internal server error")
+}
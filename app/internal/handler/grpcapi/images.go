@@ -0,0 +1,78 @@
+package grpcapi
+
+import (
+	"context"
+	"market/app/internal/apperr"
+	"market/app/internal/handler/grpcapi/pb"
+	"net/http"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxUploadSize — тот же лимит, что у multipart-загрузки в REST
+const maxUploadSize = 10 << 20
+
+type imagesServer struct {
+	pb.UnimplementedImagesServiceServer
+	img Img
+}
+
+func (s *imagesServer) UploadImage(ctx context.Context, req *pb.UploadImageRequest) (*pb.Image, error) {
+	userId := userIdFrom(ctx)
+	if userId == "" {
+		return nil, errUnauthenticated
+	}
+	if err := uuid.Validate(req.GetAdId()); err != nil {
+		return nil, toStatus(apperr.ErrInvalidUUID)
+	}
+
+	data := req.GetData()
+	if len(data) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "image is empty")
+	}
+	if len(data) > maxUploadSize {
+		return nil, status.Error(codes.InvalidArgument, "file is too large")
+	}
+
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return nil, toStatus(apperr.ErrUnsupportedFileType)
+	}
+
+	img, err := s.img.AddImage(req.GetAdId(), userId, data, contentType)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toImage(img), nil
+}
+
+func (s *imagesServer) ListImages(ctx context.Context, req *pb.ListImagesRequest) (*pb.ListImagesResponse, error) {
+	if err := uuid.Validate(req.GetAdId()); err != nil {
+		return nil, toStatus(apperr.ErrInvalidUUID)
+	}
+
+	images, err := s.img.GetImages(req.GetAdId(), userIdFrom(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &pb.ListImagesResponse{Images: make([]*pb.Image, 0, len(images))}
+	for _, img := range images {
+		res.Images = append(res.Images, toImage(img))
+	}
+	return res, nil
+}
+
+func (s *imagesServer) GetImage(ctx context.Context, req *pb.GetImageRequest) (*pb.Image, error) {
+	if err := uuid.Validate(req.GetId()); err != nil {
+		return nil, toStatus(apperr.ErrInvalidUUID)
+	}
+
+	img, err := s.img.GetImageById(req.GetId(), userIdFrom(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toImage(img), nil
+}
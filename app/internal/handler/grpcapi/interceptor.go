@@ -0,0 +1,36 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// authInterceptor — аналог OptionalAuth для gRPC: токен берётся из метаданных
+// authorization (Bearer <token>), id пользователя кладётся в контекст под "user_id".
+// Методы, которым нужен пользователь, сами проверяют его наличие.
+// Переданный, но недействительный токен отклоняется сразу.
+func authInterceptor(auth Auth) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 || values[0] == "" {
+			return handler(ctx, req)
+		}
+
+		token := strings.TrimPrefix(values[0], "Bearer ")
+		userId, err := auth.ValidateSession(token)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+
+		return handler(context.WithValue(ctx, "user_id", userId), req)
+	}
+}
+
+func userIdFrom(ctx context.Context) string {
+	userId, _ := ctx.Value("user_id").(string)
+	return userId
+}
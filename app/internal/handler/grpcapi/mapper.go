@@ -0,0 +1,133 @@
+package grpcapi
+
+import (
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"market/app/internal/handler/grpcapi/pb"
+	"market/app/internal/usecases/ads/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toFilter — те же проверки, что у ParseFilter в REST; диапазоны проверяет usecase
+func toFilter(req *pb.ListAdsRequest) (entity.AdsFilter, error) {
+	filter := entity.AdsFilter{
+		Limit:       int(req.GetLimit()),
+		Offset:      int(req.GetOffset()),
+		PriceMin:    req.GetPriceMin(),
+		PriceMax:    req.GetPriceMax(),
+		PricePreset: req.GetPricePreset(),
+		Tags:        req.GetTags(),
+	}
+
+	if v := req.GetAuthorId(); v != "" {
+		if err := uuid.Validate(v); err != nil {
+			return entity.AdsFilter{}, apperr.NewFieldError("author_id", "must be a UUID")
+		}
+		filter.AuthorId = v
+	}
+	if req.CreatedAfter != nil {
+		filter.CreatedAfter = req.GetCreatedAfter().AsTime()
+	}
+	if req.CreatedBefore != nil {
+		filter.CreatedBefore = req.GetCreatedBefore().AsTime()
+	}
+	if req.HasImages != nil {
+		hasImages := req.GetHasImages()
+		filter.HasImages = &hasImages
+	}
+
+	switch req.GetTagsMode() {
+	case pb.TagsMode_TAGS_MODE_UNSPECIFIED, pb.TagsMode_TAGS_MODE_ANY:
+	case pb.TagsMode_TAGS_MODE_ALL:
+		filter.TagsMatchAll = true
+	default:
+		return entity.AdsFilter{}, apperr.NewFieldError("tags_mode", "must be any or all")
+	}
+
+	for _, key := range req.GetSort() {
+		filter.Sort = append(filter.Sort, entity.SortKey{Field: key.GetField(), Desc: key.GetDesc()})
+	}
+	return filter, nil
+}
+
+func toAd(ad dto.AdResponse) *pb.Ad {
+	return &pb.Ad{
+		Id:          ad.Id,
+		Title:       ad.Title,
+		Description: ad.Description,
+		Price:       ad.Price,
+		CreatedAt:   timestamppb.New(ad.CreatedAt),
+		AuthorId:    ad.AuthorID,
+		Author:      ad.Author,
+		IsOwner:     ad.IsOwner,
+		Images:      ad.Images,
+		Published:   ad.Published,
+		PublishAt:   optionalTime(ad.PublishAt),
+		Tags:        ad.Tags,
+		Quantity:    int32(ad.Quantity),
+		SoldOut:     ad.Quantity == 0,
+	}
+}
+
+func toAdEntity(ad entity.Ad) *pb.Ad {
+	return &pb.Ad{
+		Id:          ad.Id,
+		Title:       ad.Title,
+		Description: ad.Description,
+		Price:       ad.Price,
+		CreatedAt:   timestamppb.New(ad.CreatedAt),
+		AuthorId:    ad.AuthorId,
+		Published:   ad.Published,
+		PublishAt:   optionalTime(ad.PublishAt),
+		Tags:        ad.Tags,
+		Quantity:    int32(ad.Quantity),
+		SoldOut:     ad.SoldOut(),
+	}
+}
+
+func toAdDetailed(res dto.AdDetailed) *pb.Ad {
+	ad := toAdEntity(res.Ad)
+	ad.Author = res.Author
+	ad.IsOwner = res.IsOwner
+	ad.ContactPhone = res.Phone
+	ad.Images = make([]string, 0, len(res.Images))
+	for _, img := range res.Images {
+		ad.Images = append(ad.Images, img.ImageURL)
+	}
+	return ad
+}
+
+func toImage(img entity.AdImage) *pb.Image {
+	return &pb.Image{
+		Id:        img.Id,
+		AdId:      img.AdId,
+		Url:       img.ImageURL,
+		CreatedAt: timestamppb.New(img.CreatedAt),
+		Variants: map[string]string{
+			entity.ImageThumb:    img.Variant(entity.ImageThumb),
+			entity.ImageMedium:   img.Variant(entity.ImageMedium),
+			entity.ImageOriginal: img.ImageURL,
+		},
+	}
+}
+
+func toUser(user entity.User) *pb.User {
+	return &pb.User{
+		Id:        user.Id,
+		Name:      user.Username,
+		Email:     user.Email,
+		Phone:     user.Phone,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+	}
+}
+
+// optionalTime — nil для нулевого времени, чтобы поле не приходило как 1970-01-01
+func optionalTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,832 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: market/v1/ads.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TagsMode int32
+
+const (
+	TagsMode_TAGS_MODE_UNSPECIFIED TagsMode = 0
+	// TAGS_MODE_ANY — хотя бы один тег (по умолчанию)
+	TagsMode_TAGS_MODE_ANY TagsMode = 1
+	// TAGS_MODE_ALL — все теги
+	TagsMode_TAGS_MODE_ALL TagsMode = 2
+)
+
+// Enum value maps for TagsMode.
+var (
+	TagsMode_name = map[int32]string{
+		0: "TAGS_MODE_UNSPECIFIED",
+		1: "TAGS_MODE_ANY",
+		2: "TAGS_MODE_ALL",
+	}
+	TagsMode_value = map[string]int32{
+		"TAGS_MODE_UNSPECIFIED": 0,
+		"TAGS_MODE_ANY":         1,
+		"TAGS_MODE_ALL":         2,
+	}
+)
+
+func (x TagsMode) Enum() *TagsMode {
+	p := new(TagsMode)
+	*p = x
+	return p
+}
+
+func (x TagsMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TagsMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_market_v1_ads_proto_enumTypes[0].Descriptor()
+}
+
+func (TagsMode) Type() protoreflect.EnumType {
+	return &file_market_v1_ads_proto_enumTypes[0]
+}
+
+func (x TagsMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TagsMode.Descriptor instead.
+func (TagsMode) EnumDescriptor() ([]byte, []int) {
+	return file_market_v1_ads_proto_rawDescGZIP(), []int{0}
+}
+
+type Ad struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	AuthorId    string                 `protobuf:"bytes,6,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// author — логин автора
+	Author    string   `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
+	IsOwner   bool     `protobuf:"varint,8,opt,name=is_owner,json=isOwner,proto3" json:"is_owner,omitempty"`
+	Images    []string `protobuf:"bytes,9,rep,name=images,proto3" json:"images,omitempty"`
+	Published bool     `protobuf:"varint,10,opt,name=published,proto3" json:"published,omitempty"`
+	// publish_at — только у отложенных объявлений
+	PublishAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	Tags      []string               `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty"`
+	Quantity  int32                  `protobuf:"varint,13,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	SoldOut   bool                   `protobuf:"varint,14,opt,name=sold_out,json=soldOut,proto3" json:"sold_out,omitempty"`
+	// contact_phone — замаскированный телефон продавца, заполняется только в GetAd
+	ContactPhone  string `protobuf:"bytes,15,opt,name=contact_phone,json=contactPhone,proto3" json:"contact_phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ad) Reset() {
+	*x = Ad{}
+	mi := &file_market_v1_ads_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ad) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ad) ProtoMessage() {}
+
+func (x *Ad) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_ads_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ad.ProtoReflect.Descriptor instead.
+func (*Ad) Descriptor() ([]byte, []int) {
+	return file_market_v1_ads_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Ad) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Ad) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Ad) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Ad) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Ad) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Ad) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *Ad) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Ad) GetIsOwner() bool {
+	if x != nil {
+		return x.IsOwner
+	}
+	return false
+}
+
+func (x *Ad) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *Ad) GetPublished() bool {
+	if x != nil {
+		return x.Published
+	}
+	return false
+}
+
+func (x *Ad) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *Ad) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Ad) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Ad) GetSoldOut() bool {
+	if x != nil {
+		return x.SoldOut
+	}
+	return false
+}
+
+func (x *Ad) GetContactPhone() string {
+	if x != nil {
+		return x.ContactPhone
+	}
+	return ""
+}
+
+type SortKey struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field — created_at, price или title
+	Field         string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Desc          bool   `protobuf:"varint,2,opt,name=desc,proto3" json:"desc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SortKey) Reset() {
+	*x = SortKey{}
+	mi := &file_market_v1_ads_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SortKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortKey) ProtoMessage() {}
+
+func (x *SortKey) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_ads_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortKey.ProtoReflect.Descriptor instead.
+func (*SortKey) Descriptor() ([]byte, []int) {
+	return file_market_v1_ads_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SortKey) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SortKey) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+// ListAdsRequest — фильтры ленты, нулевые значения означают «без ограничения»
+type ListAdsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// limit — 1–100, по умолчанию 10
+	Limit  int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// sort — по умолчанию свежие в начале
+	Sort          []*SortKey             `protobuf:"bytes,3,rep,name=sort,proto3" json:"sort,omitempty"`
+	PriceMin      float64                `protobuf:"fixed64,4,opt,name=price_min,json=priceMin,proto3" json:"price_min,omitempty"`
+	PriceMax      float64                `protobuf:"fixed64,5,opt,name=price_max,json=priceMax,proto3" json:"price_max,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,6,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	HasImages     *bool                  `protobuf:"varint,9,opt,name=has_images,json=hasImages,proto3,oneof" json:"has_images,omitempty"`
+	Tags          []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	TagsMode      TagsMode               `protobuf:"varint,11,opt,name=tags_mode,json=tagsMode,proto3,enum=market.v1.TagsMode" json:"tags_mode,omitempty"`
+	// price_preset — budget, mid или premium; price_min и price_max сужают диапазон
+	PricePreset   string `protobuf:"bytes,12,opt,name=price_preset,json=pricePreset,proto3" json:"price_preset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAdsRequest) Reset() {
+	*x = ListAdsRequest{}
+	mi := &file_market_v1_ads_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAdsRequest) ProtoMessage() {}
+
+func (x *ListAdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_ads_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAdsRequest.ProtoReflect.Descriptor instead.
+func (*ListAdsRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_ads_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListAdsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAdsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListAdsRequest) GetSort() []*SortKey {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *ListAdsRequest) GetPriceMin() float64 {
+	if x != nil {
+		return x.PriceMin
+	}
+	return 0
+}
+
+func (x *ListAdsRequest) GetPriceMax() float64 {
+	if x != nil {
+		return x.PriceMax
+	}
+	return 0
+}
+
+func (x *ListAdsRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ListAdsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListAdsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListAdsRequest) GetHasImages() bool {
+	if x != nil && x.HasImages != nil {
+		return *x.HasImages
+	}
+	return false
+}
+
+func (x *ListAdsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListAdsRequest) GetTagsMode() TagsMode {
+	if x != nil {
+		return x.TagsMode
+	}
+	return TagsMode_TAGS_MODE_UNSPECIFIED
+}
+
+func (x *ListAdsRequest) GetPricePreset() string {
+	if x != nil {
+		return x.PricePreset
+	}
+	return ""
+}
+
+type ListAdsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ads           []*Ad                  `protobuf:"bytes,1,rep,name=ads,proto3" json:"ads,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAdsResponse) Reset() {
+	*x = ListAdsResponse{}
+	mi := &file_market_v1_ads_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAdsResponse) ProtoMessage() {}
+
+func (x *ListAdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_ads_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAdsResponse.ProtoReflect.Descriptor instead.
+func (*ListAdsResponse) Descriptor() ([]byte, []int) {
+	return file_market_v1_ads_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListAdsResponse) GetAds() []*Ad {
+	if x != nil {
+		return x.Ads
+	}
+	return nil
+}
+
+type GetAdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAdRequest) Reset() {
+	*x = GetAdRequest{}
+	mi := &file_market_v1_ads_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAdRequest) ProtoMessage() {}
+
+func (x *GetAdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_ads_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAdRequest.ProtoReflect.Descriptor instead.
+func (*GetAdRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_ads_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetAdRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateAdRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Price       float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	// publish_at — отложенная публикация, время в будущем
+	PublishAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	Tags      []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	// quantity — по умолчанию 1
+	Quantity      *int32 `protobuf:"varint,6,opt,name=quantity,proto3,oneof" json:"quantity,omitempty"`
+	ContactPhone  string `protobuf:"bytes,7,opt,name=contact_phone,json=contactPhone,proto3" json:"contact_phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAdRequest) Reset() {
+	*x = CreateAdRequest{}
+	mi := &file_market_v1_ads_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAdRequest) ProtoMessage() {}
+
+func (x *CreateAdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_ads_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAdRequest.ProtoReflect.Descriptor instead.
+func (*CreateAdRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_ads_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateAdRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateAdRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateAdRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateAdRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *CreateAdRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateAdRequest) GetQuantity() int32 {
+	if x != nil && x.Quantity != nil {
+		return *x.Quantity
+	}
+	return 0
+}
+
+func (x *CreateAdRequest) GetContactPhone() string {
+	if x != nil {
+		return x.ContactPhone
+	}
+	return ""
+}
+
+type DeleteAdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAdRequest) Reset() {
+	*x = DeleteAdRequest{}
+	mi := &file_market_v1_ads_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAdRequest) ProtoMessage() {}
+
+func (x *DeleteAdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_ads_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAdRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAdRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_ads_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteAdRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteAdResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAdResponse) Reset() {
+	*x = DeleteAdResponse{}
+	mi := &file_market_v1_ads_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAdResponse) ProtoMessage() {}
+
+func (x *DeleteAdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_ads_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAdResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAdResponse) Descriptor() ([]byte, []int) {
+	return file_market_v1_ads_proto_rawDescGZIP(), []int{7}
+}
+
+var File_market_v1_ads_proto protoreflect.FileDescriptor
+
+const file_market_v1_ads_proto_rawDesc = "" +
+	"\n" +
+	"\x13market/v1/ads.proto\x12\tmarket.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xce\x03\n" +
+	"\x02Ad\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1b\n" +
+	"\tauthor_id\x18\x06 \x01(\tR\bauthorId\x12\x16\n" +
+	"\x06author\x18\a \x01(\tR\x06author\x12\x19\n" +
+	"\bis_owner\x18\b \x01(\bR\aisOwner\x12\x16\n" +
+	"\x06images\x18\t \x03(\tR\x06images\x12\x1c\n" +
+	"\tpublished\x18\n" +
+	" \x01(\bR\tpublished\x129\n" +
+	"\n" +
+	"publish_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12\x12\n" +
+	"\x04tags\x18\f \x03(\tR\x04tags\x12\x1a\n" +
+	"\bquantity\x18\r \x01(\x05R\bquantity\x12\x19\n" +
+	"\bsold_out\x18\x0e \x01(\bR\asoldOut\x12#\n" +
+	"\rcontact_phone\x18\x0f \x01(\tR\fcontactPhone\"3\n" +
+	"\aSortKey\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04desc\x18\x02 \x01(\bR\x04desc\"\xdd\x03\n" +
+	"\x0eListAdsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12&\n" +
+	"\x04sort\x18\x03 \x03(\v2\x12.market.v1.SortKeyR\x04sort\x12\x1b\n" +
+	"\tprice_min\x18\x04 \x01(\x01R\bpriceMin\x12\x1b\n" +
+	"\tprice_max\x18\x05 \x01(\x01R\bpriceMax\x12\x1b\n" +
+	"\tauthor_id\x18\x06 \x01(\tR\bauthorId\x12?\n" +
+	"\rcreated_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\"\n" +
+	"\n" +
+	"has_images\x18\t \x01(\bH\x00R\thasImages\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x120\n" +
+	"\ttags_mode\x18\v \x01(\x0e2\x13.market.v1.TagsModeR\btagsMode\x12!\n" +
+	"\fprice_preset\x18\f \x01(\tR\vpricePresetB\r\n" +
+	"\v_has_images\"2\n" +
+	"\x0fListAdsResponse\x12\x1f\n" +
+	"\x03ads\x18\x01 \x03(\v2\r.market.v1.AdR\x03ads\"\x1e\n" +
+	"\fGetAdRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x81\x02\n" +
+	"\x0fCreateAdRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x129\n" +
+	"\n" +
+	"publish_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1f\n" +
+	"\bquantity\x18\x06 \x01(\x05H\x00R\bquantity\x88\x01\x01\x12#\n" +
+	"\rcontact_phone\x18\a \x01(\tR\fcontactPhoneB\v\n" +
+	"\t_quantity\"!\n" +
+	"\x0fDeleteAdRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x12\n" +
+	"\x10DeleteAdResponse*K\n" +
+	"\bTagsMode\x12\x19\n" +
+	"\x15TAGS_MODE_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rTAGS_MODE_ANY\x10\x01\x12\x11\n" +
+	"\rTAGS_MODE_ALL\x10\x022\xfb\x01\n" +
+	"\n" +
+	"AdsService\x12@\n" +
+	"\aListAds\x12\x19.market.v1.ListAdsRequest\x1a\x1a.market.v1.ListAdsResponse\x12/\n" +
+	"\x05GetAd\x12\x17.market.v1.GetAdRequest\x1a\r.market.v1.Ad\x125\n" +
+	"\bCreateAd\x12\x1a.market.v1.CreateAdRequest\x1a\r.market.v1.Ad\x12C\n" +
+	"\bDeleteAd\x12\x1a.market.v1.DeleteAdRequest\x1a\x1b.market.v1.DeleteAdResponseB+Z)market/app/internal/handler/grpcapi/pb;pbb\x06proto3"
+
+var (
+	file_market_v1_ads_proto_rawDescOnce sync.Once
+	file_market_v1_ads_proto_rawDescData []byte
+)
+
+func file_market_v1_ads_proto_rawDescGZIP() []byte {
+	file_market_v1_ads_proto_rawDescOnce.Do(func() {
+		file_market_v1_ads_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_market_v1_ads_proto_rawDesc), len(file_market_v1_ads_proto_rawDesc)))
+	})
+	return file_market_v1_ads_proto_rawDescData
+}
+
+var file_market_v1_ads_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_market_v1_ads_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_market_v1_ads_proto_goTypes = []any{
+	(TagsMode)(0),                 // 0: market.v1.TagsMode
+	(*Ad)(nil),                    // 1: market.v1.Ad
+	(*SortKey)(nil),               // 2: market.v1.SortKey
+	(*ListAdsRequest)(nil),        // 3: market.v1.ListAdsRequest
+	(*ListAdsResponse)(nil),       // 4: market.v1.ListAdsResponse
+	(*GetAdRequest)(nil),          // 5: market.v1.GetAdRequest
+	(*CreateAdRequest)(nil),       // 6: market.v1.CreateAdRequest
+	(*DeleteAdRequest)(nil),       // 7: market.v1.DeleteAdRequest
+	(*DeleteAdResponse)(nil),      // 8: market.v1.DeleteAdResponse
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+}
+var file_market_v1_ads_proto_depIdxs = []int32{
+	9,  // 0: market.v1.Ad.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 1: market.v1.Ad.publish_at:type_name -> google.protobuf.Timestamp
+	2,  // 2: market.v1.ListAdsRequest.sort:type_name -> market.v1.SortKey
+	9,  // 3: market.v1.ListAdsRequest.created_after:type_name -> google.protobuf.Timestamp
+	9,  // 4: market.v1.ListAdsRequest.created_before:type_name -> google.protobuf.Timestamp
+	0,  // 5: market.v1.ListAdsRequest.tags_mode:type_name -> market.v1.TagsMode
+	1,  // 6: market.v1.ListAdsResponse.ads:type_name -> market.v1.Ad
+	9,  // 7: market.v1.CreateAdRequest.publish_at:type_name -> google.protobuf.Timestamp
+	3,  // 8: market.v1.AdsService.ListAds:input_type -> market.v1.ListAdsRequest
+	5,  // 9: market.v1.AdsService.GetAd:input_type -> market.v1.GetAdRequest
+	6,  // 10: market.v1.AdsService.CreateAd:input_type -> market.v1.CreateAdRequest
+	7,  // 11: market.v1.AdsService.DeleteAd:input_type -> market.v1.DeleteAdRequest
+	4,  // 12: market.v1.AdsService.ListAds:output_type -> market.v1.ListAdsResponse
+	1,  // 13: market.v1.AdsService.GetAd:output_type -> market.v1.Ad
+	1,  // 14: market.v1.AdsService.CreateAd:output_type -> market.v1.Ad
+	8,  // 15: market.v1.AdsService.DeleteAd:output_type -> market.v1.DeleteAdResponse
+	12, // [12:16] is the sub-list for method output_type
+	8,  // [8:12] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_market_v1_ads_proto_init() }
+func file_market_v1_ads_proto_init() {
+	if File_market_v1_ads_proto != nil {
+		return
+	}
+	file_market_v1_ads_proto_msgTypes[2].OneofWrappers = []any{}
+	file_market_v1_ads_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_market_v1_ads_proto_rawDesc), len(file_market_v1_ads_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_market_v1_ads_proto_goTypes,
+		DependencyIndexes: file_market_v1_ads_proto_depIdxs,
+		EnumInfos:         file_market_v1_ads_proto_enumTypes,
+		MessageInfos:      file_market_v1_ads_proto_msgTypes,
+	}.Build()
+	File_market_v1_ads_proto = out.File
+	file_market_v1_ads_proto_goTypes = nil
+	file_market_v1_ads_proto_depIdxs = nil
+}
@@ -0,0 +1,241 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: market/v1/ads.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AdsService_ListAds_FullMethodName  = "/market.v1.AdsService/ListAds"
+	AdsService_GetAd_FullMethodName    = "/market.v1.AdsService/GetAd"
+	AdsService_CreateAd_FullMethodName = "/market.v1.AdsService/CreateAd"
+	AdsService_DeleteAd_FullMethodName = "/market.v1.AdsService/DeleteAd"
+)
+
+// AdsServiceClient is the client API for AdsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdsService — те же операции с объявлениями, что и в REST API.
+// ListAds и GetAd работают без токена, но с ним отмечают свои объявления is_owner
+type AdsServiceClient interface {
+	ListAds(ctx context.Context, in *ListAdsRequest, opts ...grpc.CallOption) (*ListAdsResponse, error)
+	GetAd(ctx context.Context, in *GetAdRequest, opts ...grpc.CallOption) (*Ad, error)
+	CreateAd(ctx context.Context, in *CreateAdRequest, opts ...grpc.CallOption) (*Ad, error)
+	DeleteAd(ctx context.Context, in *DeleteAdRequest, opts ...grpc.CallOption) (*DeleteAdResponse, error)
+}
+
+type adsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdsServiceClient(cc grpc.ClientConnInterface) AdsServiceClient {
+	return &adsServiceClient{cc}
+}
+
+func (c *adsServiceClient) ListAds(ctx context.Context, in *ListAdsRequest, opts ...grpc.CallOption) (*ListAdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAdsResponse)
+	err := c.cc.Invoke(ctx, AdsService_ListAds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adsServiceClient) GetAd(ctx context.Context, in *GetAdRequest, opts ...grpc.CallOption) (*Ad, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ad)
+	err := c.cc.Invoke(ctx, AdsService_GetAd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adsServiceClient) CreateAd(ctx context.Context, in *CreateAdRequest, opts ...grpc.CallOption) (*Ad, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ad)
+	err := c.cc.Invoke(ctx, AdsService_CreateAd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adsServiceClient) DeleteAd(ctx context.Context, in *DeleteAdRequest, opts ...grpc.CallOption) (*DeleteAdResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAdResponse)
+	err := c.cc.Invoke(ctx, AdsService_DeleteAd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdsServiceServer is the server API for AdsService service.
+// All implementations must embed UnimplementedAdsServiceServer
+// for forward compatibility.
+//
+// AdsService — те же операции с объявлениями, что и в REST API.
+// ListAds и GetAd работают без токена, но с ним отмечают свои объявления is_owner
+type AdsServiceServer interface {
+	ListAds(context.Context, *ListAdsRequest) (*ListAdsResponse, error)
+	GetAd(context.Context, *GetAdRequest) (*Ad, error)
+	CreateAd(context.Context, *CreateAdRequest) (*Ad, error)
+	DeleteAd(context.Context, *DeleteAdRequest) (*DeleteAdResponse, error)
+	mustEmbedUnimplementedAdsServiceServer()
+}
+
+// UnimplementedAdsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdsServiceServer struct{}
+
+func (UnimplementedAdsServiceServer) ListAds(context.Context, *ListAdsRequest) (*ListAdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAds not implemented")
+}
+func (UnimplementedAdsServiceServer) GetAd(context.Context, *GetAdRequest) (*Ad, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAd not implemented")
+}
+func (UnimplementedAdsServiceServer) CreateAd(context.Context, *CreateAdRequest) (*Ad, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAd not implemented")
+}
+func (UnimplementedAdsServiceServer) DeleteAd(context.Context, *DeleteAdRequest) (*DeleteAdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAd not implemented")
+}
+func (UnimplementedAdsServiceServer) mustEmbedUnimplementedAdsServiceServer() {}
+func (UnimplementedAdsServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeAdsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdsServiceServer will
+// result in compilation errors.
+type UnsafeAdsServiceServer interface {
+	mustEmbedUnimplementedAdsServiceServer()
+}
+
+func RegisterAdsServiceServer(s grpc.ServiceRegistrar, srv AdsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdsService_ServiceDesc, srv)
+}
+
+func _AdsService_ListAds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdsServiceServer).ListAds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdsService_ListAds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdsServiceServer).ListAds(ctx, req.(*ListAdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdsService_GetAd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdsServiceServer).GetAd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdsService_GetAd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdsServiceServer).GetAd(ctx, req.(*GetAdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdsService_CreateAd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdsServiceServer).CreateAd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdsService_CreateAd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdsServiceServer).CreateAd(ctx, req.(*CreateAdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdsService_DeleteAd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdsServiceServer).DeleteAd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdsService_DeleteAd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdsServiceServer).DeleteAd(ctx, req.(*DeleteAdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdsService_ServiceDesc is the grpc.ServiceDesc for AdsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "market.v1.AdsService",
+	HandlerType: (*AdsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAds",
+			Handler:    _AdsService_ListAds_Handler,
+		},
+		{
+			MethodName: "GetAd",
+			Handler:    _AdsService_GetAd_Handler,
+		},
+		{
+			MethodName: "CreateAd",
+			Handler:    _AdsService_CreateAd_Handler,
+		},
+		{
+			MethodName: "DeleteAd",
+			Handler:    _AdsService_DeleteAd_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "market/v1/ads.proto",
+}
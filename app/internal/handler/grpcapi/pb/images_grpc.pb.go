@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: market/v1/images.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ImagesService_UploadImage_FullMethodName = "/market.v1.ImagesService/UploadImage"
+	ImagesService_ListImages_FullMethodName  = "/market.v1.ImagesService/ListImages"
+	ImagesService_GetImage_FullMethodName    = "/market.v1.ImagesService/GetImage"
+)
+
+// ImagesServiceClient is the client API for ImagesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ImagesService — изображения объявлений. Загрузка требует токена
+type ImagesServiceClient interface {
+	// UploadImage — JPEG или PNG до 10 МБ, тип определяется по содержимому.
+	// Загружать может только автор объявления, иначе PERMISSION_DENIED.
+	// Сверх лимита изображений в объявлении или квоты пользователя — RESOURCE_EXHAUSTED
+	UploadImage(ctx context.Context, in *UploadImageRequest, opts ...grpc.CallOption) (*Image, error)
+	ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ListImagesResponse, error)
+	GetImage(ctx context.Context, in *GetImageRequest, opts ...grpc.CallOption) (*Image, error)
+}
+
+type imagesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewImagesServiceClient(cc grpc.ClientConnInterface) ImagesServiceClient {
+	return &imagesServiceClient{cc}
+}
+
+func (c *imagesServiceClient) UploadImage(ctx context.Context, in *UploadImageRequest, opts ...grpc.CallOption) (*Image, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Image)
+	err := c.cc.Invoke(ctx, ImagesService_UploadImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imagesServiceClient) ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ListImagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListImagesResponse)
+	err := c.cc.Invoke(ctx, ImagesService_ListImages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imagesServiceClient) GetImage(ctx context.Context, in *GetImageRequest, opts ...grpc.CallOption) (*Image, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Image)
+	err := c.cc.Invoke(ctx, ImagesService_GetImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ImagesServiceServer is the server API for ImagesService service.
+// All implementations must embed UnimplementedImagesServiceServer
+// for forward compatibility.
+//
+// ImagesService — изображения объявлений. Загрузка требует токена
+type ImagesServiceServer interface {
+	// UploadImage — JPEG или PNG до 10 МБ, тип определяется по содержимому.
+	// Загружать может только автор объявления, иначе PERMISSION_DENIED.
+	// Сверх лимита изображений в объявлении или квоты пользователя — RESOURCE_EXHAUSTED
+	UploadImage(context.Context, *UploadImageRequest) (*Image, error)
+	ListImages(context.Context, *ListImagesRequest) (*ListImagesResponse, error)
+	GetImage(context.Context, *GetImageRequest) (*Image, error)
+	mustEmbedUnimplementedImagesServiceServer()
+}
+
+// UnimplementedImagesServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedImagesServiceServer struct{}
+
+func (UnimplementedImagesServiceServer) UploadImage(context.Context, *UploadImageRequest) (*Image, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadImage not implemented")
+}
+func (UnimplementedImagesServiceServer) ListImages(context.Context, *ListImagesRequest) (*ListImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListImages not implemented")
+}
+func (UnimplementedImagesServiceServer) GetImage(context.Context, *GetImageRequest) (*Image, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetImage not implemented")
+}
+func (UnimplementedImagesServiceServer) mustEmbedUnimplementedImagesServiceServer() {}
+func (UnimplementedImagesServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeImagesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ImagesServiceServer will
+// result in compilation errors.
+type UnsafeImagesServiceServer interface {
+	mustEmbedUnimplementedImagesServiceServer()
+}
+
+func RegisterImagesServiceServer(s grpc.ServiceRegistrar, srv ImagesServiceServer) {
+	// If the following call pancis, it indicates UnimplementedImagesServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ImagesService_ServiceDesc, srv)
+}
+
+func _ImagesService_UploadImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServiceServer).UploadImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ImagesService_UploadImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServiceServer).UploadImage(ctx, req.(*UploadImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImagesService_ListImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServiceServer).ListImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ImagesService_ListImages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServiceServer).ListImages(ctx, req.(*ListImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImagesService_GetImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServiceServer).GetImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ImagesService_GetImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServiceServer).GetImage(ctx, req.(*GetImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ImagesService_ServiceDesc is the grpc.ServiceDesc for ImagesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ImagesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "market.v1.ImagesService",
+	HandlerType: (*ImagesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UploadImage",
+			Handler:    _ImagesService_UploadImage_Handler,
+		},
+		{
+			MethodName: "ListImages",
+			Handler:    _ImagesService_ListImages_Handler,
+		},
+		{
+			MethodName: "GetImage",
+			Handler:    _ImagesService_GetImage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "market/v1/images.proto",
+}
@@ -0,0 +1,24 @@
+package grpcapi
+
+import (
+	"market/app/internal/handler/grpcapi/pb"
+
+	"google.golang.org/grpc"
+)
+
+// maxMessageSize — изображение до 10 МБ плюс запас на остальные поля
+const maxMessageSize = maxUploadSize + 1<<20
+
+// NewServer — gRPC-сервер с сервисами auth, ads и images поверх тех же usecase-ов, что и REST
+func NewServer(ads Ads, img Img, auth Auth, reg Registry) *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(authInterceptor(auth)),
+		grpc.MaxRecvMsgSize(maxMessageSize),
+	)
+
+	pb.RegisterAuthServiceServer(srv, &authServer{auth: auth, reg: reg})
+	pb.RegisterAdsServiceServer(srv, &adsServer{ads: ads})
+	pb.RegisterImagesServiceServer(srv, &imagesServer{img: img})
+
+	return srv
+}
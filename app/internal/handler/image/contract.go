@@ -3,7 +3,11 @@ package image
 import "market/app/internal/entity"
 
 type Img interface {
-	AddImage(adId string, data []byte, ext string) (entity.AdImage, error)
-	GetImages(adId string) ([]entity.AdImage, error)
-	GetImageById(id string) (entity.AdImage, error)
+	AddImage(adId, userId string, data []byte, ext string) (entity.AdImage, error)
+	GetImages(adId, viewerId string) ([]entity.AdImage, error)
+	GetImageById(id, viewerId string) (entity.AdImage, error)
+	DeleteImage(imgId, userId string) error
+	ReorderImages(adId, userId string, imgIds []string) ([]entity.AdImage, error)
+	StorageUsage(userId string) (entity.StorageUsage, error)
+	SetImagesPrivate(adId, userId string, private bool) ([]entity.AdImage, error)
 }
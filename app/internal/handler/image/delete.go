@@ -0,0 +1,78 @@
+package image
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"log"
+	"market/app/internal/apperr"
+	"market/app/internal/handler/image/dto"
+	"net/http"
+)
+
+// DeleteImage godoc
+// @Summary      Удалить изображение
+// @Description  Удаляет изображение объявления вместе с файлом. Только владелец объявления. Требует авторизации.
+// @Tags         image
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID изображения"
+// @Success      200  {string}  string  "OK"
+// @Failure      400  {object}  dto.Err400BadRequest    "Некорректный ID"
+// @Failure      401  {object}  dto.Err401Unauthorized  "Пользователь не авторизован"
+// @Failure      403  {object}  dto.Err403Forbidden     "Изображение чужого объявления"
+// @Failure      404  {object}  dto.Err404ImageNotFound "Изображение не найдено"
+// @Failure      500  {object}  dto.Err500Internal      "Внутренняя ошибка сервера"
+// @Router       /api/v1/ads/images/{id} [delete]
+func (i *ImageHandler) DeleteImage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, ok := r.Context().Value("user_id").(string)
+	if !ok || userId == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Message: "unauthorized",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if err := uuid.Validate(id); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Message: "invalid image id",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	err := i.img.DeleteImage(id, userId)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrImgNotFound), errors.Is(err, apperr.ErrAddNotFound):
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Message: "image not found",
+				Code:    http.StatusNotFound,
+			})
+		case errors.Is(err, apperr.ErrForbidden):
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Message: "you are not the owner of this ad",
+				Code:    http.StatusForbidden,
+			})
+		default:
+			log.Println(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Message: "internal server error",
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
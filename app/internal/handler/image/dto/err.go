@@ -21,6 +21,41 @@ type Err401Unauthorized struct {
 	Code    int    `json:"code" example:"401"`
 }
 
+type Err401PrivateImages struct {
+	Message string `json:"message" example:"images of this ad are visible only to logged-in users"`
+	Code    int    `json:"code" example:"401"`
+}
+
+type Err403Forbidden struct {
+	Message string `json:"message" example:"you are not the owner of this ad"`
+	Code    int    `json:"code" example:"403"`
+}
+
+type Err404ImageNotFound struct {
+	Message string `json:"message" example:"image not found"`
+	Code    int    `json:"code" example:"404"`
+}
+
+type Err400InvalidOrder struct {
+	Message string `json:"message" example:"image ids must list every image of the ad exactly once"`
+	Code    int    `json:"code" example:"400"`
+}
+
+type Err409ImageLimit struct {
+	Message string `json:"message" example:"ad already has the maximum number of images"`
+	Code    int    `json:"code" example:"409"`
+}
+
+type Err409PrivateUnavailable struct {
+	Message string `json:"message" example:"private images are unavailable while storage serves files from a public url"`
+	Code    int    `json:"code" example:"409"`
+}
+
+type Err413StorageQuota struct {
+	Message string `json:"message" example:"storage quota exceeded"`
+	Code    int    `json:"code" example:"413"`
+}
+
 type Err404AdNotFound struct {
 	Message string `json:"message" example:"ad not found"`
 	Code    int    `json:"code" example:"404"`
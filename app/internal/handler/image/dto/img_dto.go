@@ -7,8 +7,29 @@ type ResponseDTO struct {
 	AdId      string    `json:"adId" example:"92d1b029-10b6-4df4-8463-b3272e4f15ee"`
 	ImageURL  string    `json:"imageUrl" example:"/static/upload/example.jpg"`
 	CreatedAt time.Time `json:"createdAt" example:"2025-07-20T12:34:56Z"`
+	// Variants — URL копий: thumb (200px), medium (800px), original
+	Variants map[string]string `json:"variants" example:"thumb:/static/upload/example_thumb.jpg,medium:/static/upload/example_medium.jpg,original:/static/upload/example.jpg"`
+	// Private — ссылки подписаны и действуют ограниченное время
+	Private bool `json:"private" example:"false"`
 }
 
 type ImagesResponseDTO struct {
 	Images []ResponseDTO `json:"images" swaggertype:"array,object"`
 }
+
+type ReorderRequestDTO struct {
+	// ImageIds — все изображения объявления в нужном порядке, первое — обложка
+	ImageIds []string `json:"image_ids" example:"f8c7e2a1-72e1-4c9e-bd84-7ae1b8fc4d4b,0b6f1d52-3c1e-4a51-9d0e-5b1a7a3f2c11"`
+}
+
+type StorageUsageDTO struct {
+	UsedBytes  int64 `json:"used_bytes" example:"1048576"`
+	QuotaBytes int64 `json:"quota_bytes" example:"209715200"`
+	// Images — сколько изображений во всех объявлениях пользователя
+	Images         int `json:"images" example:"3"`
+	MaxImagesPerAd int `json:"max_images_per_ad" example:"10"`
+}
+
+type PrivacyRequestDTO struct {
+	Private bool `json:"private" example:"true"`
+}
@@ -23,7 +23,7 @@ func NewImageHandler(img Img) *ImageHandler {
 
 // AddImage godoc
 // @Summary      Загрузить изображение для объявления
-// @Description  Загружает изображение в формате JPEG или PNG для указанного объявления. Требует авторизации.
+// @Description  Загружает изображение в формате JPEG или PNG для указанного объявления, перекодирует его без метаданных (EXIF) и создаёт уменьшенные копии (`variants`: thumb 200px, medium 800px, original). Только автор объявления. Число изображений в объявлении и суммарный размер изображений пользователя ограничены. Требует авторизации.
 // @Tags         image
 // @Accept       multipart/form-data
 // @Produce      json
@@ -31,15 +31,28 @@ func NewImageHandler(img Img) *ImageHandler {
 // @Param        id     path      string           true  "ID объявления"
 // @Param        image  formData  file             true  "Изображение (jpeg или png)"
 // @Success      201    {object}  dto.ResponseDTO               "Успешная загрузка изображения"
-// @Failure      400    {object}  dto.Err400BadRequest          "Невалидный файл или данные"
+// @Failure      400    {object}  dto.Err400BadRequest          "Невалидный файл или данные, изображение не декодируется"
 // @Failure      401    {object}  dto.Err401Unauthorized        "Пользователь не авторизован"
+// @Failure      403    {object}  dto.Err403Forbidden           "Объявление другого пользователя"
 // @Failure      404    {object}  dto.Err404AdNotFound          "Объявление не найдено"
+// @Failure      409    {object}  dto.Err409ImageLimit          "В объявлении уже максимум изображений"
+// @Failure      413    {object}  dto.Err413StorageQuota        "Превышена квота пользователя на место"
 // @Failure      415    {object}  dto.Err415                        "Неподдерживаемый тип файла"
 // @Failure      500    {object}  dto.Err500Internal            "Внутренняя ошибка сервера"
 // @Router       /api/v1/ads/{id}/images [post]
 func (i *ImageHandler) AddImage(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
+	userId, ok := r.Context().Value("user_id").(string)
+	if !ok || userId == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Message: "unauthorized",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
 	adID := mux.Vars(r)["id"]
 
 	if err := uuid.Validate(adID); err != nil {
@@ -102,7 +115,7 @@ func (i *ImageHandler) AddImage(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
-	res, err := i.img.AddImage(adID, imgBytes, ext)
+	res, err := i.img.AddImage(adID, userId, imgBytes, ext)
 	if err != nil {
 		if errors.Is(err, apperr.ErrAddNotFound) {
 			w.WriteHeader(http.StatusNotFound)
@@ -112,7 +125,40 @@ func (i *ImageHandler) AddImage(w http.ResponseWriter, r *http.Request) {
 			})
 			return
 		}
+		if errors.Is(err, apperr.ErrInvalidImage) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "image cannot be decoded",
+			})
+			return
+		}
+		if errors.Is(err, apperr.ErrForbidden) {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Code:    http.StatusForbidden,
+				Message: "you are not the owner of this ad",
+			})
+			return
+		}
+		if errors.Is(err, apperr.ErrImageLimitReached) {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Code:    http.StatusConflict,
+				Message: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, apperr.ErrStorageQuota) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Code:    http.StatusRequestEntityTooLarge,
+				Message: err.Error(),
+			})
+			return
+		}
 
+		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(dto.ErrResponse{
 			Code:    http.StatusInternalServerError,
 			Message: "internal server error",
@@ -126,12 +172,13 @@ func (i *ImageHandler) AddImage(w http.ResponseWriter, r *http.Request) {
 
 // GetImages godoc
 // @Summary      Получить изображения объявления
-// @Description  Возвращает список изображений, прикреплённых к объявлению
+// @Description  Возвращает список изображений, прикреплённых к объявлению. Закрытые изображения видны только с токеном и отдаются по подписанным ссылкам с ограниченным сроком.
 // @Tags         image
 // @Produce      json
 // @Param        id   path      string  true  "ID объявления"
 // @Success      200  {array}   dto.ResponseDTO                 "Список изображений"
 // @Failure      400  {object}  dto.Err400BadRequest            "Некорректный ID"
+// @Failure      401  {object}  dto.Err401PrivateImages         "Изображения закрыты, нужен токен"
 // @Failure      404  {object}  dto.ErrImagesNotFoundExample           "Изображения не найдены или объявление не существует"
 // @Failure      500  {object}  dto.Err500Internal              "Внутренняя ошибка сервера"
 // @Router       /api/v1/ads/{id}/images [get]
@@ -158,8 +205,21 @@ func (i *ImageHandler) GetImages(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := i.img.GetImages(adID)
+	var userId string
+	if id, ok := r.Context().Value("user_id").(string); ok {
+		userId = id
+	}
+
+	res, err := i.img.GetImages(adID, userId)
 	if err != nil {
+		if errors.Is(err, apperr.ErrPrivateImages) {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			})
+			return
+		}
 		if errors.Is(err, apperr.ErrAddNotFound) {
 			w.WriteHeader(http.StatusNotFound)
 			json.NewEncoder(w).Encode(dto.ErrResponse{
@@ -192,12 +252,13 @@ func (i *ImageHandler) GetImages(w http.ResponseWriter, r *http.Request) {
 
 // GetImageById godoc
 // @Summary      Получить изображение по ID
-// @Description  Возвращает одно изображение по его ID
+// @Description  Возвращает одно изображение по его ID. Закрытое изображение видно только с токеном.
 // @Tags         image
 // @Produce      json
 // @Param        id   path      string  true  "ID изображения"
 // @Success      200  {object}  dto.ResponseDTO                 "Данные изображения"
 // @Failure      400  {object}  dto.Err400BadRequest            "ID изображения не указан"
+// @Failure      401  {object}  dto.Err401PrivateImages         "Изображение закрыто, нужен токен"
 // @Failure      404  {object}  dto.Err404AdNotFound            "Изображение не найдено"
 // @Failure      500  {object}  dto.Err500Internal              "Внутренняя ошибка сервера"
 // @Router       /api/v1/ads/images/{id} [get]
@@ -223,8 +284,21 @@ func (i *ImageHandler) GetImageById(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := i.img.GetImageById(id)
+	var userId string
+	if uid, ok := r.Context().Value("user_id").(string); ok {
+		userId = uid
+	}
+
+	res, err := i.img.GetImageById(id, userId)
 	if err != nil {
+		if errors.Is(err, apperr.ErrPrivateImages) {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			})
+			return
+		}
 		if errors.Is(err, apperr.ErrImgNotFound) {
 			w.WriteHeader(http.StatusNotFound)
 			json.NewEncoder(w).Encode(dto.ErrResponse{
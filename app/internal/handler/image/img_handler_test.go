@@ -0,0 +1,117 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/png"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"market/app/internal/handler/image/dto"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	testAdId    = "7c0d2d4e-4a57-4c43-9d0e-6a4c2f3b8e11"
+	testOwnerId = "1f9a7b52-3c1e-4b8d-a0f4-5e2d9c6b7a30"
+	testOtherId = "9b3e5c71-2d4f-4a6e-8c1b-0f7e6d5a4b92"
+)
+
+// fakeImg — usecase изображений: загружать может только testOwnerId
+type fakeImg struct {
+	Img
+	calls int
+}
+
+func (f *fakeImg) AddImage(adId, userId string, data []byte, ext string) (entity.AdImage, error) {
+	f.calls++
+	if userId != testOwnerId {
+		return entity.AdImage{}, apperr.ErrForbidden
+	}
+	return entity.AdImage{
+		Id:       "3d6f8a10-5b2c-4e7d-9f1a-2c4b6d8e0f13",
+		AdId:     adId,
+		ImageURL: "/static/upload/a.png",
+		Variants: map[string]string{entity.ImageOriginal: "/static/upload/a.png"},
+	}, nil
+}
+
+func uploadRequest(t *testing.T, userId string) *http.Request {
+	t.Helper()
+
+	var img bytes.Buffer
+	if err := png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatal(err)
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("image", "a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(img.Bytes())
+	form.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/ads/"+testAdId+"/images", &body)
+	r.Header.Set("Content-Type", form.FormDataContentType())
+	r = mux.SetURLVars(r, map[string]string{"id": testAdId})
+	if userId != "" {
+		r = r.WithContext(context.WithValue(r.Context(), "user_id", userId))
+	}
+	return r
+}
+
+func TestAddImageHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		userId    string
+		wantCode  int
+		wantCalls int
+	}{
+		{name: "no user", userId: "", wantCode: http.StatusUnauthorized},
+		{name: "not owner", userId: testOtherId, wantCode: http.StatusForbidden, wantCalls: 1},
+		{name: "owner", userId: testOwnerId, wantCode: http.StatusCreated, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := &fakeImg{}
+			w := httptest.NewRecorder()
+
+			NewImageHandler(img).AddImage(w, uploadRequest(t, tt.userId))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantCode, w.Body)
+			}
+			if img.calls != tt.wantCalls {
+				t.Errorf("usecase calls = %d, want %d", img.calls, tt.wantCalls)
+			}
+
+			if tt.wantCode != http.StatusCreated {
+				var res dto.ErrResponse
+				if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+					t.Fatal(err)
+				}
+				if res.Code != tt.wantCode {
+					t.Errorf("body code = %d, want %d", res.Code, tt.wantCode)
+				}
+				return
+			}
+
+			var res dto.ResponseDTO
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			if res.AdId != testAdId {
+				t.Errorf("ad id = %q, want %q", res.AdId, testAdId)
+			}
+		})
+	}
+}
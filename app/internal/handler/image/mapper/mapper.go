@@ -11,6 +11,17 @@ func EntityImageToDTO(image entity.AdImage) dto.ResponseDTO {
 		AdId:      image.AdId,
 		ImageURL:  image.ImageURL,
 		CreatedAt: image.CreatedAt,
+		Variants:  Variants(image),
+		Private:   image.Private,
+	}
+}
+
+// Variants — все размеры; для старых изображений без копий — везде оригинал
+func Variants(image entity.AdImage) map[string]string {
+	return map[string]string{
+		entity.ImageThumb:    image.Variant(entity.ImageThumb),
+		entity.ImageMedium:   image.Variant(entity.ImageMedium),
+		entity.ImageOriginal: image.ImageURL,
 	}
 }
 
@@ -25,3 +36,12 @@ func EntityImagesToDTO(images []entity.AdImage) dto.ImagesResponseDTO {
 	}
 	return res
 }
+
+func StorageUsageToDTO(usage entity.StorageUsage) dto.StorageUsageDTO {
+	return dto.StorageUsageDTO{
+		UsedBytes:      usage.UsedBytes,
+		QuotaBytes:     usage.QuotaBytes,
+		Images:         usage.Images,
+		MaxImagesPerAd: usage.MaxImagesPerAd,
+	}
+}
@@ -0,0 +1,108 @@
+package image
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"log"
+	"market/app/internal/apperr"
+	"market/app/internal/handler/image/dto"
+	"market/app/internal/handler/image/mapper"
+	"net/http"
+)
+
+// ReorderImages godoc
+// @Summary      Изменить порядок изображений
+// @Description  Принимает полный список ID изображений объявления в нужном порядке; первое изображение становится обложкой в ленте. Список должен содержать каждое изображение объявления ровно один раз. Только владелец объявления. Требует авторизации.
+// @Tags         image
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                 true  "ID объявления"
+// @Param        body  body      dto.ReorderRequestDTO  true  "Новый порядок изображений"
+// @Success      200   {object}  dto.ImagesResponseDTO    "Изображения в новом порядке"
+// @Failure      400   {object}  dto.Err400InvalidOrder   "Некорректный ID или список не совпадает с изображениями объявления"
+// @Failure      401   {object}  dto.Err401Unauthorized   "Пользователь не авторизован"
+// @Failure      403   {object}  dto.Err403Forbidden      "Объявление другого пользователя"
+// @Failure      404   {object}  dto.Err404AdNotFound     "Объявление не найдено"
+// @Failure      500   {object}  dto.Err500Internal       "Внутренняя ошибка сервера"
+// @Router       /api/v1/ads/{id}/images/order [patch]
+func (i *ImageHandler) ReorderImages(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, ok := r.Context().Value("user_id").(string)
+	if !ok || userId == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Message: "unauthorized",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	adID := mux.Vars(r)["id"]
+	if err := uuid.Validate(adID); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Message: "invalid ad id",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	var req dto.ReorderRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Message: "invalid request body",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	for _, id := range req.ImageIds {
+		if err := uuid.Validate(id); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Message: "invalid image id: " + id,
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+	}
+
+	res, err := i.img.ReorderImages(adID, userId, req.ImageIds)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrAddNotFound):
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Message: "ad not found",
+				Code:    http.StatusNotFound,
+			})
+		case errors.Is(err, apperr.ErrForbidden):
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Message: "you are not the owner of this ad",
+				Code:    http.StatusForbidden,
+			})
+		case errors.Is(err, apperr.ErrInvalidImageOrder):
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+		default:
+			log.Println(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Message: "internal server error",
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapper.EntityImagesToDTO(res))
+}
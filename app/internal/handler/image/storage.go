@@ -0,0 +1,47 @@
+package image
+
+import (
+	"encoding/json"
+	"log"
+	"market/app/internal/handler/image/dto"
+	"market/app/internal/handler/image/mapper"
+	"net/http"
+)
+
+// StorageUsage godoc
+// @Summary      Занятое место под изображения
+// @Description  Сколько байт занимают изображения во всех объявлениях пользователя (оригиналы и копии), квота и лимит изображений на объявление; 0 — без ограничения. Требует авторизации.
+// @Tags         image
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  dto.StorageUsageDTO     "Использование и лимиты"
+// @Failure      401  {object}  dto.Err401Unauthorized  "Пользователь не авторизован"
+// @Failure      500  {object}  dto.Err500Internal      "Внутренняя ошибка сервера"
+// @Router       /api/v1/me/storage [get]
+func (i *ImageHandler) StorageUsage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, ok := r.Context().Value("user_id").(string)
+	if !ok || userId == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Message: "unauthorized",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	res, err := i.img.StorageUsage(userId)
+	if err != nil {
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Message: "internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapper.StorageUsageToDTO(res))
+}
@@ -0,0 +1,11 @@
+package offers
+
+import "market/app/internal/entity"
+
+type Offers interface {
+	Create(offer entity.Offer) (entity.Offer, error)
+	GetByAd(adId, userId string) ([]entity.Offer, error)
+	Accept(offerId, userId string, hideAd bool) (entity.Offer, error)
+	Reject(offerId, userId string) (entity.Offer, error)
+	Counter(offerId, userId string, amount float64, message string, hideAd bool) (entity.Offer, error)
+}
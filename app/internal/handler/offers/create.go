@@ -0,0 +1,72 @@
+package offers
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"market/app/internal/handler/offers/dto"
+	"market/app/internal/handler/offers/mapper"
+	"net/http"
+)
+
+// Create godoc
+// @Summary      Предложить цену
+// @Description  Покупатель предлагает свою цену за объявление. Предложение действует 72 часа. Требует авторизации.
+// @Tags         offers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path  string              true  "ID объявления"
+// @Param        offer  body  dto.OfferCreateDTO  true  "Предложение"
+// @Success      201  {object}  dto.OfferResponseDTO
+// @Failure      400  {object}  dto.ErrOffer400
+// @Failure      401  {object}  dto.ErrOffer401
+// @Failure      404  {object}  dto.ErrOffer404
+// @Failure      409  {object}  dto.ErrOffer409
+// @Failure      500  {object}  dto.ErrOffer500
+// @Router       /api/v1/ads/{id}/offers [post]
+func (o *OffersHandler) Create(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+
+	userId, ok := r.Context().Value("user_id").(string)
+	if !ok || userId == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	adId := mux.Vars(r)["id"]
+	if err := uuid.Validate(adId); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid ad id",
+		})
+		return
+	}
+
+	var req dto.OfferCreateDTO
+	defer r.Body.Close()
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid JSON",
+		})
+		return
+	}
+
+	offer, err := o.offers.Create(mapper.ToOfferEntity(req, adId, userId))
+	if err != nil {
+		errResp := o.compareErr(err)
+		w.WriteHeader(errResp.Code)
+		json.NewEncoder(w).Encode(errResp)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(mapper.EntityOfferToDTO(offer))
+}
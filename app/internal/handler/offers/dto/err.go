@@ -0,0 +1,41 @@
+package dto
+
+type ErrResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type ErrOffer400 struct {
+	Message string `json:"message" example:"offer amount is invalid"`
+	Code    int    `json:"code" example:"400"`
+}
+
+type ErrOffer401 struct {
+	Message string `json:"message" example:"unauthorized"`
+	Code    int    `json:"code" example:"401"`
+}
+
+type ErrOffer403 struct {
+	Message string `json:"message" example:"you are not allowed to act on this offer"`
+	Code    int    `json:"code" example:"403"`
+}
+
+type ErrOffer404 struct {
+	Message string `json:"message" example:"offer not found"`
+	Code    int    `json:"code" example:"404"`
+}
+
+type ErrOffer409 struct {
+	Message string `json:"message" example:"offer cannot move to this state"`
+	Code    int    `json:"code" example:"409"`
+}
+
+type ErrOffer410 struct {
+	Message string `json:"message" example:"offer expired"`
+	Code    int    `json:"code" example:"410"`
+}
+
+type ErrOffer500 struct {
+	Message string `json:"message" example:"internal server error"`
+	Code    int    `json:"code" example:"500"`
+}
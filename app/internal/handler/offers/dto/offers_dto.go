@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+type OfferCreateDTO struct {
+	Amount  float64 `json:"amount" example:"4500"`
+	Message string  `json:"message" example:"Заберу сегодня вечером"`
+}
+
+type OfferAcceptDTO struct {
+	HideAd bool `json:"hide_ad" example:"true"`
+}
+
+type OfferCounterDTO struct {
+	Amount  float64 `json:"amount" example:"4800"`
+	Message string  `json:"message" example:"Могу уступить до 4800"`
+	HideAd  bool    `json:"hide_ad" example:"true"`
+}
+
+type OfferResponseDTO struct {
+	Id             string    `json:"id" example:"0c5e4a0e-8a1f-4a8e-9d57-3c2b1c1b9f10"`
+	AdId           string    `json:"ad_id" example:"b72f25de-3ef1-4a36-9675-df31545fa08c"`
+	BuyerId        string    `json:"buyer_id" example:"a491c857-dbd0-4a4a-88dc-123456789abc"`
+	Amount         float64   `json:"amount" example:"4500"`
+	Message        string    `json:"message" example:"Заберу сегодня вечером"`
+	CounterAmount  float64   `json:"counter_amount,omitempty" example:"4800"`
+	CounterMessage string    `json:"counter_message,omitempty" example:"Могу уступить до 4800"`
+	HideAd         bool      `json:"hide_ad" example:"false"`
+	Status         string    `json:"status" example:"pending"`
+	CreatedAt      time.Time `json:"created_at" example:"2025-07-20T12:34:56Z"`
+	UpdatedAt      time.Time `json:"updated_at" example:"2025-07-20T12:34:56Z"`
+	ExpiresAt      time.Time `json:"expires_at" example:"2025-07-23T12:34:56Z"`
+}
+
+type OffersResponseDTO struct {
+	Offers []OfferResponseDTO `json:"offers"`
+}
@@ -0,0 +1,58 @@
+package offers
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"market/app/internal/handler/offers/dto"
+	"market/app/internal/handler/offers/mapper"
+	"net/http"
+)
+
+// GetByAd godoc
+// @Summary      Предложения по объявлению
+// @Description  Владелец объявления видит все предложения, остальные — только свои. Требует авторизации.
+// @Tags         offers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID объявления"
+// @Success      200  {object}  dto.OffersResponseDTO
+// @Failure      400  {object}  dto.ErrOffer400
+// @Failure      401  {object}  dto.ErrOffer401
+// @Failure      404  {object}  dto.ErrOffer404
+// @Failure      500  {object}  dto.ErrOffer500
+// @Router       /api/v1/ads/{id}/offers [get]
+func (o *OffersHandler) GetByAd(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, ok := r.Context().Value("user_id").(string)
+	if !ok || userId == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	adId := mux.Vars(r)["id"]
+	if err := uuid.Validate(adId); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid ad id",
+		})
+		return
+	}
+
+	res, err := o.offers.GetByAd(adId, userId)
+	if err != nil {
+		errResp := o.compareErr(err)
+		w.WriteHeader(errResp.Code)
+		json.NewEncoder(w).Encode(errResp)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapper.EntityOffersToDTO(res))
+}
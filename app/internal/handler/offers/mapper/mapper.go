@@ -0,0 +1,42 @@
+package mapper
+
+import (
+	"market/app/internal/entity"
+	"market/app/internal/handler/offers/dto"
+)
+
+func ToOfferEntity(data dto.OfferCreateDTO, adId, buyerId string) entity.Offer {
+	return entity.Offer{
+		AdId:    adId,
+		BuyerId: buyerId,
+		Amount:  data.Amount,
+		Message: data.Message,
+	}
+}
+
+func EntityOfferToDTO(offer entity.Offer) dto.OfferResponseDTO {
+	return dto.OfferResponseDTO{
+		Id:             offer.Id,
+		AdId:           offer.AdId,
+		BuyerId:        offer.BuyerId,
+		Amount:         offer.Amount,
+		Message:        offer.Message,
+		CounterAmount:  offer.CounterAmount,
+		CounterMessage: offer.CounterMessage,
+		HideAd:         offer.HideAd,
+		Status:         string(offer.Status),
+		CreatedAt:      offer.CreatedAt,
+		UpdatedAt:      offer.UpdatedAt,
+		ExpiresAt:      offer.ExpiresAt,
+	}
+}
+
+func EntityOffersToDTO(offers []entity.Offer) dto.OffersResponseDTO {
+	var res dto.OffersResponseDTO
+
+	res.Offers = make([]dto.OfferResponseDTO, 0, len(offers))
+	for _, offer := range offers {
+		res.Offers = append(res.Offers, EntityOfferToDTO(offer))
+	}
+	return res
+}
@@ -0,0 +1,82 @@
+package offers
+
+import (
+	"errors"
+	"market/app/internal/apperr"
+	"market/app/internal/handler/offers/dto"
+	"net/http"
+)
+
+type OffersHandler struct {
+	offers Offers
+}
+
+func NewOffersHandler(offers Offers) *OffersHandler {
+	return &OffersHandler{
+		offers: offers,
+	}
+}
+
+func (o *OffersHandler) compareErr(err error) dto.ErrResponse {
+	var res dto.ErrResponse
+
+	switch {
+	case errors.Is(err, apperr.ErrInvalidOfferAmount):
+		res = dto.ErrResponse{
+			Message: apperr.ErrInvalidOfferAmount.Error(),
+			Code:    http.StatusBadRequest,
+		}
+	case errors.Is(err, apperr.ErrOfferMessageTooLong):
+		res = dto.ErrResponse{
+			Message: apperr.ErrOfferMessageTooLong.Error(),
+			Code:    http.StatusBadRequest,
+		}
+	case errors.Is(err, apperr.ErrOfferOnOwnAd):
+		res = dto.ErrResponse{
+			Message: apperr.ErrOfferOnOwnAd.Error(),
+			Code:    http.StatusBadRequest,
+		}
+	case errors.Is(err, apperr.ErrAdsNotFound):
+		res = dto.ErrResponse{
+			Message: "ad not found",
+			Code:    http.StatusNotFound,
+		}
+	case errors.Is(err, apperr.ErrOfferNotFound):
+		res = dto.ErrResponse{
+			Message: "offer not found",
+			Code:    http.StatusNotFound,
+		}
+	case errors.Is(err, apperr.ErrForbidden):
+		res = dto.ErrResponse{
+			Message: "you are not allowed to act on this offer",
+			Code:    http.StatusForbidden,
+		}
+	case errors.Is(err, apperr.ErrOfferTransition):
+		res = dto.ErrResponse{
+			Message: apperr.ErrOfferTransition.Error(),
+			Code:    http.StatusConflict,
+		}
+	case errors.Is(err, apperr.ErrOfferAlreadyAccepted):
+		res = dto.ErrResponse{
+			Message: apperr.ErrOfferAlreadyAccepted.Error(),
+			Code:    http.StatusConflict,
+		}
+	case errors.Is(err, apperr.ErrOfferAdClosed):
+		res = dto.ErrResponse{
+			Message: apperr.ErrOfferAdClosed.Error(),
+			Code:    http.StatusConflict,
+		}
+	case errors.Is(err, apperr.ErrOfferExpired):
+		res = dto.ErrResponse{
+			Message: "offer expired",
+			Code:    http.StatusGone,
+		}
+	default:
+		res = dto.ErrResponse{
+			Message: "internal server error",
+			Code:    http.StatusInternalServerError,
+		}
+	}
+
+	return res
+}
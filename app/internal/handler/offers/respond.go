@@ -0,0 +1,170 @@
+package offers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"io"
+	"market/app/internal/handler/offers/dto"
+	"market/app/internal/handler/offers/mapper"
+	"net/http"
+)
+
+// Accept godoc
+// @Summary      Принять предложение
+// @Description  Продавец принимает предложение покупателя или покупатель — встречное предложение продавца. Остальные открытые предложения по объявлению отклоняются. С `hide_ad=true` объявление скрывается из ленты. Требует авторизации.
+// @Tags         offers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path  string              true   "ID предложения"
+// @Param        body  body  dto.OfferAcceptDTO  false  "Параметры"
+// @Success      200  {object}  dto.OfferResponseDTO
+// @Failure      400  {object}  dto.ErrOffer400
+// @Failure      401  {object}  dto.ErrOffer401
+// @Failure      403  {object}  dto.ErrOffer403
+// @Failure      404  {object}  dto.ErrOffer404
+// @Failure      409  {object}  dto.ErrOffer409
+// @Failure      410  {object}  dto.ErrOffer410
+// @Failure      500  {object}  dto.ErrOffer500
+// @Router       /api/v1/offers/{id}/accept [post]
+func (o *OffersHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, offerId, ok := o.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.OfferAcceptDTO
+	defer r.Body.Close()
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid JSON",
+		})
+		return
+	}
+
+	res, err := o.offers.Accept(offerId, userId, req.HideAd)
+	if err != nil {
+		errResp := o.compareErr(err)
+		w.WriteHeader(errResp.Code)
+		json.NewEncoder(w).Encode(errResp)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapper.EntityOfferToDTO(res))
+}
+
+// Reject godoc
+// @Summary      Отклонить предложение
+// @Description  Продавец отклоняет предложение покупателя или покупатель — встречное предложение продавца. Требует авторизации.
+// @Tags         offers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID предложения"
+// @Success      200  {object}  dto.OfferResponseDTO
+// @Failure      400  {object}  dto.ErrOffer400
+// @Failure      401  {object}  dto.ErrOffer401
+// @Failure      403  {object}  dto.ErrOffer403
+// @Failure      404  {object}  dto.ErrOffer404
+// @Failure      409  {object}  dto.ErrOffer409
+// @Failure      410  {object}  dto.ErrOffer410
+// @Failure      500  {object}  dto.ErrOffer500
+// @Router       /api/v1/offers/{id}/reject [post]
+func (o *OffersHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, offerId, ok := o.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := o.offers.Reject(offerId, userId)
+	if err != nil {
+		errResp := o.compareErr(err)
+		w.WriteHeader(errResp.Code)
+		json.NewEncoder(w).Encode(errResp)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapper.EntityOfferToDTO(res))
+}
+
+// Counter godoc
+// @Summary      Встречное предложение
+// @Description  Продавец отвечает на предложение своей ценой. `hide_ad` — скрыть объявление из ленты, если покупатель примет встречную цену. Требует авторизации.
+// @Tags         offers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string               true  "ID предложения"
+// @Param        counter  body  dto.OfferCounterDTO  true  "Встречное предложение"
+// @Success      200  {object}  dto.OfferResponseDTO
+// @Failure      400  {object}  dto.ErrOffer400
+// @Failure      401  {object}  dto.ErrOffer401
+// @Failure      403  {object}  dto.ErrOffer403
+// @Failure      404  {object}  dto.ErrOffer404
+// @Failure      409  {object}  dto.ErrOffer409
+// @Failure      410  {object}  dto.ErrOffer410
+// @Failure      500  {object}  dto.ErrOffer500
+// @Router       /api/v1/offers/{id}/counter [post]
+func (o *OffersHandler) Counter(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, offerId, ok := o.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.OfferCounterDTO
+	defer r.Body.Close()
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid JSON",
+		})
+		return
+	}
+
+	res, err := o.offers.Counter(offerId, userId, req.Amount, req.Message, req.HideAd)
+	if err != nil {
+		errResp := o.compareErr(err)
+		w.WriteHeader(errResp.Code)
+		json.NewEncoder(w).Encode(errResp)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapper.EntityOfferToDTO(res))
+}
+
+// parseRequest — достаёт пользователя из контекста и ID предложения из пути, при ошибке сам пишет ответ
+func (o *OffersHandler) parseRequest(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	userId, ok := r.Context().Value("user_id").(string)
+	if !ok || userId == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "unauthorized",
+		})
+		return "", "", false
+	}
+
+	offerId := mux.Vars(r)["id"]
+	if err := uuid.Validate(offerId); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid offer id",
+		})
+		return "", "", false
+	}
+	return userId, offerId, true
+}
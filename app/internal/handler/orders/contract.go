@@ -0,0 +1,15 @@
+package orders
+
+import (
+	"market/app/internal/entity"
+	"market/app/internal/usecases/orders/dto"
+)
+
+type Orders interface {
+	Create(adId, buyerId string) (entity.Order, error)
+	GetById(orderId, userId string) (dto.OrderDetailed, error)
+	GetByUser(userId string) ([]entity.Order, error)
+	Confirm(orderId, userId string) (entity.Order, error)
+	Receive(orderId, userId string) (entity.Order, error)
+	Cancel(orderId, userId string) (entity.Order, error)
+}
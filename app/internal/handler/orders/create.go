@@ -0,0 +1,42 @@
+package orders
+
+import (
+	"encoding/json"
+	"market/app/internal/handler/orders/mapper"
+	"net/http"
+)
+
+// Create godoc
+// @Summary      Оформить заказ
+// @Description  Покупатель оформляет заказ по объявлению, деньги блокируются у платёжного провайдера. Цена — из принятого предложения, если оно есть, иначе цена объявления. По распроданному объявлению — 409. Требует авторизации.
+// @Tags         orders
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID объявления"
+// @Success      201  {object}  dto.OrderResponseDTO
+// @Failure      400  {object}  dto.ErrOrder400
+// @Failure      401  {object}  dto.ErrOrder401
+// @Failure      402  {object}  dto.ErrOrder402
+// @Failure      404  {object}  dto.ErrOrder404
+// @Failure      409  {object}  dto.ErrOrder409
+// @Failure      500  {object}  dto.ErrOrder500
+// @Router       /api/v1/ads/{id}/orders [post]
+func (o *OrdersHandler) Create(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+
+	userId, adId, ok := o.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	order, err := o.orders.Create(adId, userId)
+	if err != nil {
+		errResp := o.compareErr(err)
+		w.WriteHeader(errResp.Code)
+		json.NewEncoder(w).Encode(errResp)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(mapper.EntityOrderToDTO(order))
+}
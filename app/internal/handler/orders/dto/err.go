@@ -0,0 +1,41 @@
+package dto
+
+type ErrResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type ErrOrder400 struct {
+	Message string `json:"message" example:"cannot order your own ad"`
+	Code    int    `json:"code" example:"400"`
+}
+
+type ErrOrder401 struct {
+	Message string `json:"message" example:"unauthorized"`
+	Code    int    `json:"code" example:"401"`
+}
+
+type ErrOrder402 struct {
+	Message string `json:"message" example:"payment failed"`
+	Code    int    `json:"code" example:"402"`
+}
+
+type ErrOrder403 struct {
+	Message string `json:"message" example:"you are not allowed to act on this order"`
+	Code    int    `json:"code" example:"403"`
+}
+
+type ErrOrder404 struct {
+	Message string `json:"message" example:"order not found"`
+	Code    int    `json:"code" example:"404"`
+}
+
+type ErrOrder409 struct {
+	Message string `json:"message" example:"order cannot move to this state"`
+	Code    int    `json:"code" example:"409"`
+}
+
+type ErrOrder500 struct {
+	Message string `json:"message" example:"internal server error"`
+	Code    int    `json:"code" example:"500"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+type OrderResponseDTO struct {
+	Id        string    `json:"id" example:"5d0f5c7e-2b1a-4f43-9a3e-6f1c0b9e2d11"`
+	AdId      string    `json:"ad_id" example:"b72f25de-3ef1-4a36-9675-df31545fa08c"`
+	BuyerId   string    `json:"buyer_id" example:"a491c857-dbd0-4a4a-88dc-123456789abc"`
+	SellerId  string    `json:"seller_id" example:"c1a2b3c4-d5e6-47f8-9a0b-1c2d3e4f5a6b"`
+	Amount    float64   `json:"amount" example:"4500"`
+	Status    string    `json:"status" example:"authorized"`
+	CreatedAt time.Time `json:"created_at" example:"2025-07-20T12:34:56Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-07-20T12:34:56Z"`
+}
+
+type OrderEventDTO struct {
+	FromStatus string    `json:"from_status" example:"authorized"`
+	ToStatus   string    `json:"to_status" example:"confirmed"`
+	ActorId    string    `json:"actor_id,omitempty" example:"c1a2b3c4-d5e6-47f8-9a0b-1c2d3e4f5a6b"`
+	Note       string    `json:"note" example:"confirmed by seller"`
+	CreatedAt  time.Time `json:"created_at" example:"2025-07-20T12:34:56Z"`
+}
+
+type OrderDetailedResponseDTO struct {
+	OrderResponseDTO
+	History []OrderEventDTO `json:"history"`
+}
+
+type OrdersResponseDTO struct {
+	Orders []OrderResponseDTO `json:"orders"`
+}
@@ -0,0 +1,77 @@
+package orders
+
+import (
+	"encoding/json"
+	"market/app/internal/handler/orders/dto"
+	"market/app/internal/handler/orders/mapper"
+	"net/http"
+)
+
+// GetById godoc
+// @Summary      Получить заказ
+// @Description  Возвращает заказ вместе с историей переходов между статусами. Доступно покупателю и продавцу.
+// @Tags         orders
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID заказа"
+// @Success      200  {object}  dto.OrderDetailedResponseDTO
+// @Failure      400  {object}  dto.ErrOrder400
+// @Failure      401  {object}  dto.ErrOrder401
+// @Failure      403  {object}  dto.ErrOrder403
+// @Failure      404  {object}  dto.ErrOrder404
+// @Failure      500  {object}  dto.ErrOrder500
+// @Router       /api/v1/orders/{id} [get]
+func (o *OrdersHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, orderId, ok := o.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := o.orders.GetById(orderId, userId)
+	if err != nil {
+		errResp := o.compareErr(err)
+		w.WriteHeader(errResp.Code)
+		json.NewEncoder(w).Encode(errResp)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapper.ToOrderDetailedDTO(res))
+}
+
+// GetMy godoc
+// @Summary      Мои заказы
+// @Description  Заказы, в которых вы покупатель или продавец. Требует авторизации.
+// @Tags         orders
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  dto.OrdersResponseDTO
+// @Failure      401  {object}  dto.ErrOrder401
+// @Failure      500  {object}  dto.ErrOrder500
+// @Router       /api/v1/orders [get]
+func (o *OrdersHandler) GetMy(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, ok := r.Context().Value("user_id").(string)
+	if !ok || userId == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	res, err := o.orders.GetByUser(userId)
+	if err != nil {
+		errResp := o.compareErr(err)
+		w.WriteHeader(errResp.Code)
+		json.NewEncoder(w).Encode(errResp)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapper.EntityOrdersToDTO(res))
+}
@@ -0,0 +1,48 @@
+package mapper
+
+import (
+	"market/app/internal/entity"
+	"market/app/internal/handler/orders/dto"
+	usecases "market/app/internal/usecases/orders/dto"
+)
+
+func EntityOrderToDTO(order entity.Order) dto.OrderResponseDTO {
+	return dto.OrderResponseDTO{
+		Id:        order.Id,
+		AdId:      order.AdId,
+		BuyerId:   order.BuyerId,
+		SellerId:  order.SellerId,
+		Amount:    order.Amount,
+		Status:    string(order.Status),
+		CreatedAt: order.CreatedAt,
+		UpdatedAt: order.UpdatedAt,
+	}
+}
+
+func EntityOrdersToDTO(orders []entity.Order) dto.OrdersResponseDTO {
+	var res dto.OrdersResponseDTO
+
+	res.Orders = make([]dto.OrderResponseDTO, 0, len(orders))
+	for _, order := range orders {
+		res.Orders = append(res.Orders, EntityOrderToDTO(order))
+	}
+	return res
+}
+
+func ToOrderDetailedDTO(data usecases.OrderDetailed) dto.OrderDetailedResponseDTO {
+	history := make([]dto.OrderEventDTO, 0, len(data.Events))
+	for _, e := range data.Events {
+		history = append(history, dto.OrderEventDTO{
+			FromStatus: string(e.FromStatus),
+			ToStatus:   string(e.ToStatus),
+			ActorId:    e.ActorId,
+			Note:       e.Note,
+			CreatedAt:  e.CreatedAt,
+		})
+	}
+
+	return dto.OrderDetailedResponseDTO{
+		OrderResponseDTO: EntityOrderToDTO(data.Order),
+		History:          history,
+	}
+}
@@ -0,0 +1,99 @@
+package orders
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"market/app/internal/apperr"
+	"market/app/internal/handler/orders/dto"
+	"net/http"
+)
+
+type OrdersHandler struct {
+	orders Orders
+}
+
+func NewOrdersHandler(orders Orders) *OrdersHandler {
+	return &OrdersHandler{
+		orders: orders,
+	}
+}
+
+func (o *OrdersHandler) compareErr(err error) dto.ErrResponse {
+	var res dto.ErrResponse
+
+	switch {
+	case errors.Is(err, apperr.ErrOrderOnOwnAd):
+		res = dto.ErrResponse{
+			Message: apperr.ErrOrderOnOwnAd.Error(),
+			Code:    http.StatusBadRequest,
+		}
+	case errors.Is(err, apperr.ErrPaymentFailed):
+		res = dto.ErrResponse{
+			Message: apperr.ErrPaymentFailed.Error(),
+			Code:    http.StatusPaymentRequired,
+		}
+	case errors.Is(err, apperr.ErrForbidden):
+		res = dto.ErrResponse{
+			Message: "you are not allowed to act on this order",
+			Code:    http.StatusForbidden,
+		}
+	case errors.Is(err, apperr.ErrAdsNotFound):
+		res = dto.ErrResponse{
+			Message: "ad not found",
+			Code:    http.StatusNotFound,
+		}
+	case errors.Is(err, apperr.ErrOrderNotFound):
+		res = dto.ErrResponse{
+			Message: "order not found",
+			Code:    http.StatusNotFound,
+		}
+	case errors.Is(err, apperr.ErrAdSoldOut):
+		res = dto.ErrResponse{
+			Message: apperr.ErrAdSoldOut.Error(),
+			Code:    http.StatusConflict,
+		}
+	case errors.Is(err, apperr.ErrOrderAdBusy):
+		res = dto.ErrResponse{
+			Message: apperr.ErrOrderAdBusy.Error(),
+			Code:    http.StatusConflict,
+		}
+	case errors.Is(err, apperr.ErrOrderTransition):
+		res = dto.ErrResponse{
+			Message: apperr.ErrOrderTransition.Error(),
+			Code:    http.StatusConflict,
+		}
+	default:
+		res = dto.ErrResponse{
+			Message: "internal server error",
+			Code:    http.StatusInternalServerError,
+		}
+	}
+
+	return res
+}
+
+// parseRequest — достаёт пользователя из контекста и ID из пути, при ошибке сам пишет ответ
+func (o *OrdersHandler) parseRequest(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	userId, ok := r.Context().Value("user_id").(string)
+	if !ok || userId == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "unauthorized",
+		})
+		return "", "", false
+	}
+
+	id := mux.Vars(r)["id"]
+	if err := uuid.Validate(id); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid id",
+		})
+		return "", "", false
+	}
+	return userId, id, true
+}
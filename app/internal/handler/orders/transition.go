@@ -0,0 +1,87 @@
+package orders
+
+import (
+	"encoding/json"
+	"market/app/internal/entity"
+	"market/app/internal/handler/orders/mapper"
+	"net/http"
+)
+
+// Confirm godoc
+// @Summary      Подтвердить заказ
+// @Description  Продавец подтверждает заказ после того, как деньги покупателя заблокированы.
+// @Tags         orders
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID заказа"
+// @Success      200  {object}  dto.OrderResponseDTO
+// @Failure      400  {object}  dto.ErrOrder400
+// @Failure      401  {object}  dto.ErrOrder401
+// @Failure      403  {object}  dto.ErrOrder403
+// @Failure      404  {object}  dto.ErrOrder404
+// @Failure      409  {object}  dto.ErrOrder409
+// @Failure      500  {object}  dto.ErrOrder500
+// @Router       /api/v1/orders/{id}/confirm [post]
+func (o *OrdersHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	o.transition(w, r, o.orders.Confirm)
+}
+
+// Receive godoc
+// @Summary      Подтвердить получение
+// @Description  Покупатель подтверждает получение товара, деньги списываются в пользу продавца.
+// @Tags         orders
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID заказа"
+// @Success      200  {object}  dto.OrderResponseDTO
+// @Failure      400  {object}  dto.ErrOrder400
+// @Failure      401  {object}  dto.ErrOrder401
+// @Failure      402  {object}  dto.ErrOrder402
+// @Failure      403  {object}  dto.ErrOrder403
+// @Failure      404  {object}  dto.ErrOrder404
+// @Failure      409  {object}  dto.ErrOrder409
+// @Failure      500  {object}  dto.ErrOrder500
+// @Router       /api/v1/orders/{id}/receive [post]
+func (o *OrdersHandler) Receive(w http.ResponseWriter, r *http.Request) {
+	o.transition(w, r, o.orders.Receive)
+}
+
+// Cancel godoc
+// @Summary      Отменить заказ
+// @Description  Покупатель или продавец отменяет заказ до его завершения, заблокированные деньги возвращаются.
+// @Tags         orders
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID заказа"
+// @Success      200  {object}  dto.OrderResponseDTO
+// @Failure      400  {object}  dto.ErrOrder400
+// @Failure      401  {object}  dto.ErrOrder401
+// @Failure      402  {object}  dto.ErrOrder402
+// @Failure      403  {object}  dto.ErrOrder403
+// @Failure      404  {object}  dto.ErrOrder404
+// @Failure      409  {object}  dto.ErrOrder409
+// @Failure      500  {object}  dto.ErrOrder500
+// @Router       /api/v1/orders/{id}/cancel [post]
+func (o *OrdersHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	o.transition(w, r, o.orders.Cancel)
+}
+
+func (o *OrdersHandler) transition(w http.ResponseWriter, r *http.Request, action func(orderId, userId string) (entity.Order, error)) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, orderId, ok := o.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := action(orderId, userId)
+	if err != nil {
+		errResp := o.compareErr(err)
+		w.WriteHeader(errResp.Code)
+		json.NewEncoder(w).Encode(errResp)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapper.EntityOrderToDTO(res))
+}
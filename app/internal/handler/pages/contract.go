@@ -0,0 +1,11 @@
+package pages
+
+import (
+	"market/app/internal/entity"
+	"market/app/internal/usecases/ads/dto"
+)
+
+type Ads interface {
+	GetAll(userId string, filter entity.AdsFilter) ([]dto.AdResponse, error)
+	GetById(adId, userId string) (dto.AdDetailed, error)
+}
@@ -0,0 +1,71 @@
+package dto
+
+import "time"
+
+// Meta — данные для <head>: заголовок, описание, canonical и превью для Open Graph / Twitter
+type Meta struct {
+	Title        string
+	Description  string
+	CanonicalURL string
+	Image        string
+	Type         string
+}
+
+type AdCard struct {
+	URL     string
+	Title   string
+	Price   string
+	Image   string
+	Author  string
+	SoldOut bool
+}
+
+type FeedPage struct {
+	Meta    Meta
+	Ads     []AdCard
+	PrevURL string
+	NextURL string
+}
+
+type AdView struct {
+	Title       string
+	Description string
+	Price       string
+	Author      string
+	Images      []string
+	Tags        []string
+	Phone       string
+	SoldOut     bool
+	CreatedAt   time.Time
+}
+
+type AdPage struct {
+	Meta    Meta
+	Ad      AdView
+	Product Product
+}
+
+type ErrorPage struct {
+	Meta    Meta
+	Code    int
+	Message string
+}
+
+// Product — разметка schema.org/Product в JSON-LD
+type Product struct {
+	Context     string   `json:"@context"`
+	Type        string   `json:"@type"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Image       []string `json:"image,omitempty"`
+	Sku         string   `json:"sku"`
+	Offers      Offer    `json:"offers"`
+}
+
+type Offer struct {
+	Type          string `json:"@type"`
+	URL           string `json:"url"`
+	Price         string `json:"price"`
+	PriceCurrency string `json:"priceCurrency"`
+	Availability  string `json:"availability"`
+}
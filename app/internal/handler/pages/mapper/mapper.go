@@ -0,0 +1,132 @@
+package mapper
+
+import (
+	"market/app/internal/entity"
+	"market/app/internal/handler/pages/dto"
+	usecases "market/app/internal/usecases/ads/dto"
+	"market/app/internal/utils"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxMetaDescription — примерно столько символов описания показывают поисковики и мессенджеры
+const maxMetaDescription = 160
+
+func ToFeedPage(baseURL string, ads []usecases.AdResponse) dto.FeedPage {
+	page := dto.FeedPage{
+		Meta: dto.Meta{
+			Title:        "Объявления — Market",
+			Description:  "Свежие объявления о продаже на Market",
+			CanonicalURL: baseURL + "/ads",
+			Type:         "website",
+		},
+		Ads: make([]dto.AdCard, 0, len(ads)),
+	}
+
+	for _, ad := range ads {
+		card := dto.AdCard{
+			URL:     "/ads/" + ad.Id,
+			Title:   ad.Title,
+			Price:   price(ad.Price),
+			Author:  ad.Author,
+			SoldOut: ad.Quantity == 0,
+		}
+		if len(ad.Images) > 0 {
+			card.Image = ad.Images[0]
+		}
+		page.Ads = append(page.Ads, card)
+	}
+
+	if len(page.Ads) > 0 && page.Ads[0].Image != "" {
+		page.Meta.Image = utils.AbsoluteURL(baseURL, page.Ads[0].Image)
+	}
+	return page
+}
+
+func ToAdPage(baseURL string, data usecases.AdDetailed) dto.AdPage {
+	ad := data.Ad
+	url := baseURL + "/ads/" + ad.Id
+
+	images := make([]string, 0, len(data.Images))
+	absImages := make([]string, 0, len(data.Images))
+	for _, img := range data.Images {
+		images = append(images, img.Variant(entity.ImageMedium))
+		absImages = append(absImages, utils.AbsoluteURL(baseURL, img.ImageURL))
+	}
+
+	availability := "https://schema.org/InStock"
+	if ad.SoldOut() {
+		availability = "https://schema.org/SoldOut"
+	}
+
+	page := dto.AdPage{
+		Meta: dto.Meta{
+			Title:        ad.Title + " — " + price(ad.Price) + " ₽",
+			Description:  metaDescription(ad.Description),
+			CanonicalURL: url,
+			Type:         "product",
+		},
+		Ad: dto.AdView{
+			Title:       ad.Title,
+			Description: ad.Description,
+			Price:       price(ad.Price),
+			Author:      data.Author,
+			Images:      images,
+			Tags:        ad.Tags,
+			Phone:       data.Phone,
+			SoldOut:     ad.SoldOut(),
+			CreatedAt:   ad.CreatedAt,
+		},
+		Product: dto.Product{
+			Context:     "https://schema.org",
+			Type:        "Product",
+			Name:        ad.Title,
+			Description: ad.Description,
+			Image:       absImages,
+			Sku:         ad.Id,
+			Offers: dto.Offer{
+				Type:          "Offer",
+				URL:           url,
+				Price:         strconv.FormatFloat(ad.Price, 'f', 2, 64),
+				PriceCurrency: "RUB",
+				Availability:  availability,
+			},
+		},
+	}
+	if len(absImages) > 0 {
+		page.Meta.Image = absImages[0]
+	}
+	return page
+}
+
+func ToErrorPage(code int, message string) dto.ErrorPage {
+	return dto.ErrorPage{
+		Meta: dto.Meta{
+			Title:       message + " — Market",
+			Description: message,
+			Type:        "website",
+		},
+		Code:    code,
+		Message: message,
+	}
+}
+
+func price(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+// metaDescription — описание в одну строку, обрезанное по границе слова
+func metaDescription(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= maxMetaDescription {
+		return s
+	}
+
+	runes := []rune(s)[:maxMetaDescription]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
@@ -0,0 +1,131 @@
+package pages
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"html/template"
+	"log"
+	"market/app/internal/apperr"
+	"market/app/internal/handler/ads"
+	"market/app/internal/handler/pages/mapper"
+	"market/app/internal/utils"
+	"net/http"
+	"strconv"
+)
+
+// feedDefaultLimit — размер страницы ленты по умолчанию, как в usecases/ads
+const feedDefaultLimit = 10
+
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+var (
+	feedTmpl  = parse("templates/feed.html")
+	adTmpl    = parse("templates/ad.html")
+	errorTmpl = parse("templates/error.html")
+)
+
+func parse(page string) *template.Template {
+	return template.Must(template.ParseFS(templatesFS, "templates/layout.html", page))
+}
+
+// PagesHandler — публичные HTML-страницы для людей, мессенджеров и поисковиков.
+// Данные те же, что у JSON API; пользовательский текст экранирует html/template.
+type PagesHandler struct {
+	ads Ads
+}
+
+func NewPagesHandler(ads Ads) *PagesHandler {
+	return &PagesHandler{ads}
+}
+
+// Feed — страница ленты, принимает те же параметры, что и GET /api/v1/ads
+func (p *PagesHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	filter, err := ads.ParseFilter(r.URL.Query())
+	if err != nil {
+		p.renderErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := p.ads.GetAll("", filter)
+	if err != nil && !errors.Is(err, apperr.ErrAdsNotFound) {
+		if errors.Is(err, apperr.ErrInvalidQueryParam) {
+			p.renderErr(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Println(err)
+		p.renderErr(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
+		return
+	}
+
+	page := mapper.ToFeedPage(utils.BaseURL(r), res)
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = feedDefaultLimit
+	}
+	if filter.Offset > 0 {
+		page.PrevURL = pageURL(r, max(filter.Offset-limit, 0))
+	}
+	if len(res) == limit {
+		page.NextURL = pageURL(r, filter.Offset+limit)
+	}
+
+	p.render(w, feedTmpl, http.StatusOK, page)
+}
+
+// Ad — страница объявления с Open Graph и разметкой schema.org/Product
+func (p *PagesHandler) Ad(w http.ResponseWriter, r *http.Request) {
+	adId := mux.Vars(r)["id"]
+	if err := uuid.Validate(adId); err != nil {
+		p.renderErr(w, http.StatusNotFound, "Объявление не найдено")
+		return
+	}
+
+	res, err := p.ads.GetById(adId, "")
+	if err != nil {
+		if errors.Is(err, apperr.ErrAdsNotFound) {
+			p.renderErr(w, http.StatusNotFound, "Объявление не найдено")
+			return
+		}
+		log.Println(err)
+		p.renderErr(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
+		return
+	}
+
+	p.render(w, adTmpl, http.StatusOK, mapper.ToAdPage(utils.BaseURL(r), res))
+}
+
+func (p *PagesHandler) renderErr(w http.ResponseWriter, code int, message string) {
+	p.render(w, errorTmpl, code, mapper.ToErrorPage(code, message))
+}
+
+// render — шаблон исполняется в буфер, чтобы при ошибке не отдать половину страницы со статусом 200
+func (p *PagesHandler) render(w http.ResponseWriter, tmpl *template.Template, code int, data any) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "layout", data); err != nil {
+		log.Println(err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	w.Write(buf.Bytes())
+}
+
+func pageURL(r *http.Request, offset int) string {
+	q := r.URL.Query()
+	if offset > 0 {
+		q.Set("offset", strconv.Itoa(offset))
+	} else {
+		q.Del("offset")
+	}
+
+	u := *r.URL
+	u.RawQuery = q.Encode()
+	return u.RequestURI()
+}
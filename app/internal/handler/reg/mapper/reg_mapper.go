@@ -10,6 +10,7 @@ func RegRequestDTOToEntity(requestDTO dto.RegUserRequestDTO) entity.User {
 		Username:     requestDTO.Name,
 		Email:        requestDTO.Email,
 		PasswordHash: requestDTO.Password,
+		Phone:        requestDTO.Phone,
 	}
 }
 
@@ -18,6 +19,7 @@ func RegResponseEntityToDTO(user entity.User) dto.RegUserResponseDTO {
 		Id:        user.Id,
 		Name:      user.Username,
 		Email:     user.Email,
+		Phone:     user.Phone,
 		CreatedAt: user.CreatedAt,
 	}
 }
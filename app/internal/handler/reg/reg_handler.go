@@ -19,7 +19,7 @@ func NewRegistryHandler(reg Registry) *RegistryHandler {
 
 // RegistrationHandler godoc
 // @Summary      Регистрация пользователя
-// @Description  Регистрирует нового пользователя по имени, email и паролю
+// @Description  Регистрирует нового пользователя по имени, email и паролю. Телефон необязателен, он показывается покупателям замаскированным.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -108,6 +108,8 @@ func (reg *RegistryHandler) badRequestErr(err error) (error, bool) {
 		return apperr.ErrNonSpecialPass, true
 	case errors.Is(err, apperr.ErrInvalidEmail):
 		return apperr.ErrInvalidEmail, true
+	case errors.Is(err, apperr.ErrInvalidPhone):
+		return apperr.ErrInvalidPhone, true
 	}
 
 	return nil, false
@@ -0,0 +1,8 @@
+package sitemap
+
+import "market/app/internal/entity"
+
+type Sitemap interface {
+	Index() ([]entity.SitemapPage, error)
+	Page(number int) ([]entity.SitemapURL, error)
+}
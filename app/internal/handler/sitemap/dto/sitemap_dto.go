@@ -0,0 +1,27 @@
+package dto
+
+import "encoding/xml"
+
+const SitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type SitemapIndex struct {
+	XMLName  xml.Name       `xml:"sitemapindex"`
+	Xmlns    string         `xml:"xmlns,attr"`
+	Sitemaps []SitemapEntry `xml:"sitemap"`
+}
+
+type SitemapEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type URLSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []URL    `xml:"url"`
+}
+
+type URL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
@@ -0,0 +1,48 @@
+package mapper
+
+import (
+	"market/app/internal/entity"
+	"market/app/internal/handler/sitemap/dto"
+	"strconv"
+	"time"
+)
+
+func ToSitemapIndex(baseURL string, pages []entity.SitemapPage) dto.SitemapIndex {
+	res := dto.SitemapIndex{
+		Xmlns:    dto.SitemapNS,
+		Sitemaps: make([]dto.SitemapEntry, 0, len(pages)),
+	}
+	for _, page := range pages {
+		res.Sitemaps = append(res.Sitemaps, dto.SitemapEntry{
+			Loc:     baseURL + PagePath(page.Number),
+			LastMod: lastMod(page.LastMod),
+		})
+	}
+	return res
+}
+
+func ToURLSet(baseURL string, urls []entity.SitemapURL) dto.URLSet {
+	res := dto.URLSet{
+		Xmlns: dto.SitemapNS,
+		URLs:  make([]dto.URL, 0, len(urls)),
+	}
+	for _, url := range urls {
+		res.URLs = append(res.URLs, dto.URL{
+			Loc:     baseURL + "/ads/" + url.AdId,
+			LastMod: lastMod(url.LastMod),
+		})
+	}
+	return res
+}
+
+func PagePath(number int) string {
+	return "/sitemaps/ads-" + strconv.Itoa(number) + ".xml"
+}
+
+// lastMod — формат W3C Datetime, пустая строка для неизвестного времени
+func lastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
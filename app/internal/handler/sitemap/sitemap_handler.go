@@ -0,0 +1,68 @@
+package sitemap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"github.com/gorilla/mux"
+	"log"
+	"market/app/internal/apperr"
+	"market/app/internal/handler/sitemap/mapper"
+	"market/app/internal/utils"
+	"net/http"
+	"strconv"
+)
+
+type SitemapHandler struct {
+	sitemap Sitemap
+}
+
+func NewSitemapHandler(sitemap Sitemap) *SitemapHandler {
+	return &SitemapHandler{sitemap}
+}
+
+// Index — /sitemap.xml, индекс страниц sitemap
+func (s *SitemapHandler) Index(w http.ResponseWriter, r *http.Request) {
+	pages, err := s.sitemap.Index()
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	writeXML(w, mapper.ToSitemapIndex(utils.BaseURL(r), pages))
+}
+
+// Page — /sitemaps/ads-{n}.xml, до 50 000 объявлений на страницу
+func (s *SitemapHandler) Page(w http.ResponseWriter, r *http.Request) {
+	number, err := strconv.Atoi(mux.Vars(r)["n"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	urls, err := s.sitemap.Page(number)
+	if err != nil {
+		if errors.Is(err, apperr.ErrSitemapPageNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		log.Println(err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	writeXML(w, mapper.ToURLSet(utils.BaseURL(r), urls))
+}
+
+func writeXML(w http.ResponseWriter, v any) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	if err := xml.NewEncoder(&buf).Encode(v); err != nil {
+		log.Println(err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,16 @@
+package static
+
+import (
+	"market/app/internal/storage"
+	"time"
+)
+
+type Blobs interface {
+	Get(key string) (storage.Object, error)
+}
+
+// Access — проверка подписанных ссылок на закрытые изображения
+type Access interface {
+	// CheckAccess — срок действия подписи или нулевое время для открытого файла
+	CheckAccess(key, exp, sig string) (time.Time, error)
+}
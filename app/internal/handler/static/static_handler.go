@@ -0,0 +1,99 @@
+package static
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gorilla/mux"
+	"log"
+	"market/app/internal/apperr"
+	"market/app/internal/storage"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// imageTypes — какие файлы из каталога загрузок можно отдавать и с каким Content-Type
+var imageTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+}
+
+// имя файла — hash содержимого, содержимое под ним никогда не меняется
+const cacheControl = "public, max-age=31536000, immutable"
+
+// StaticHandler — отдаёт загруженные изображения из хранилища
+type StaticHandler struct {
+	blobs  Blobs
+	access Access
+}
+
+func NewStaticHandler(blobs Blobs, access Access) *StaticHandler {
+	return &StaticHandler{blobs: blobs, access: access}
+}
+
+// ServeImage — GET/HEAD /static/upload/{name}. Range, If-None-Match и
+// If-Modified-Since обрабатывает http.ServeContent. Закрытые изображения
+// отдаются только по подписанной ссылке (?exp=...&sig=...) до её срока.
+func (s *StaticHandler) ServeImage(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	contentType, ok := imageTypes[strings.ToLower(filepath.Ext(name))]
+	if !ok || !validName(name) {
+		http.NotFound(w, r)
+		return
+	}
+
+	q := r.URL.Query()
+	expires, err := s.access.CheckAccess(name, q.Get("exp"), q.Get("sig"))
+	if err != nil {
+		if errors.Is(err, apperr.ErrSignatureRequired) || errors.Is(err, apperr.ErrInvalidSignature) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		log.Println("check image access failed:", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	obj, err := s.blobs.Get(name)
+	if err != nil {
+		if !errors.Is(err, apperr.ErrBlobNotFound) {
+			log.Println("open image failed:", err)
+		}
+		http.NotFound(w, r)
+		return
+	}
+	defer obj.Body.Close()
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if expires.IsZero() {
+		w.Header().Set("Cache-Control", cacheControl)
+	} else {
+		// подписанную ссылку не кэшируем в общих кэшах и дольше её срока
+		maxAge := int(time.Until(expires).Seconds())
+		w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(max(maxAge, 0)))
+	}
+	w.Header().Set("ETag", etag(obj))
+
+	http.ServeContent(w, r, name, obj.ModTime, obj.Body)
+}
+
+// validName — только имя файла в самом каталоге, без подкаталогов и скрытых файлов
+func validName(name string) bool {
+	return name != "" &&
+		!strings.HasPrefix(name, ".") &&
+		!strings.ContainsAny(name, `/\`) &&
+		filepath.Base(name) == name
+}
+
+// etag — по размеру и времени изменения, чтобы не читать файл целиком
+func etag(obj storage.Object) string {
+	return fmt.Sprintf(`"%s-%s"`,
+		strconv.FormatInt(obj.ModTime.UnixNano(), 36),
+		strconv.FormatInt(obj.Size, 36),
+	)
+}
@@ -0,0 +1,7 @@
+package stream
+
+import "market/app/internal/events"
+
+type Hub interface {
+	Subscribe(userId string) *events.Subscription
+}
@@ -0,0 +1,21 @@
+package dto
+
+type ErrResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type ErrEvents400 struct {
+	Message string `json:"message" example:"mine must be true or false"`
+	Code    int    `json:"code" example:"400"`
+}
+
+type ErrEvents401 struct {
+	Message string `json:"message" example:"unauthorized"`
+	Code    int    `json:"code" example:"401"`
+}
+
+type ErrEvents500 struct {
+	Message string `json:"message" example:"streaming unsupported"`
+	Code    int    `json:"code" example:"500"`
+}
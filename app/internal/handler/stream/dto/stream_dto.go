@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+type EventDTO struct {
+	Id        uint64         `json:"id" example:"42"`
+	Type      string         `json:"type" example:"ad_created"`
+	AdId      string         `json:"ad_id" example:"b72f25de-3ef1-4a36-9675-df31545fa08c"`
+	CreatedAt time.Time      `json:"created_at" example:"2025-07-20T12:34:56Z"`
+	Ad        *EventAdDTO    `json:"ad,omitempty"`
+	Image     *EventImageDTO `json:"image,omitempty"`
+}
+
+type EventAdDTO struct {
+	Id          string    `json:"id" example:"b72f25de-3ef1-4a36-9675-df31545fa08c"`
+	Title       string    `json:"title" example:"Велосипед"`
+	Description string    `json:"description" example:"Горный велосипед в хорошем состоянии"`
+	Price       float64   `json:"price" example:"5000"`
+	CreatedAt   time.Time `json:"created_at" example:"2025-07-20T12:34:56Z"`
+	AuthorId    string    `json:"author_id" example:"a491c857-dbd0-4a4a-88dc-123456789abc"`
+}
+
+type EventImageDTO struct {
+	Id        string            `json:"id" example:"f8c7e2a1-72e1-4c9e-bd84-7ae1b8fc4d4b"`
+	AdId      string            `json:"adId" example:"92d1b029-10b6-4df4-8463-b3272e4f15ee"`
+	ImageURL  string            `json:"imageUrl" example:"/static/upload/example.jpg"`
+	CreatedAt time.Time         `json:"createdAt" example:"2025-07-20T12:34:56Z"`
+	Variants  map[string]string `json:"variants" example:"thumb:/static/upload/example_thumb.jpg,medium:/static/upload/example_medium.jpg,original:/static/upload/example.jpg"`
+	Private   bool              `json:"private" example:"false"`
+}
@@ -0,0 +1,41 @@
+package mapper
+
+import (
+	"market/app/internal/events"
+	"market/app/internal/handler/stream/dto"
+)
+
+func EventToDTO(e events.Event) dto.EventDTO {
+	res := dto.EventDTO{
+		Id:        e.Id,
+		Type:      string(e.Type),
+		AdId:      e.AdId,
+		CreatedAt: e.CreatedAt,
+	}
+
+	if e.Ad != nil {
+		res.Ad = &dto.EventAdDTO{
+			Id:          e.Ad.Id,
+			Title:       e.Ad.Title,
+			Description: e.Ad.Description,
+			Price:       e.Ad.Price,
+			CreatedAt:   e.Ad.CreatedAt,
+			AuthorId:    e.Ad.AuthorId,
+		}
+	}
+
+	if e.Image != nil {
+		res.Image = &dto.EventImageDTO{
+			Id:        e.Image.Id,
+			AdId:      e.Image.AdId,
+			CreatedAt: e.Image.CreatedAt,
+			Private:   e.Image.Private,
+		}
+		// подписчики могут быть анонимными, ссылки на закрытые изображения не рассылаются
+		if !e.Image.Private {
+			res.Image.ImageURL = e.Image.ImageURL
+			res.Image.Variants = e.Image.Variants
+		}
+	}
+	return res
+}
@@ -0,0 +1,190 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"market/app/internal/handler/stream/dto"
+	"market/app/internal/handler/stream/mapper"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	heartbeatInterval = 15 * time.Second
+	writeTimeout      = 10 * time.Second
+)
+
+type StreamHandler struct {
+	hub      Hub
+	upgrader websocket.Upgrader
+}
+
+func NewStreamHandler(hub Hub) *StreamHandler {
+	return &StreamHandler{
+		hub: hub,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+		},
+	}
+}
+
+// Stream godoc
+// @Summary      Поток событий ленты (SSE)
+// @Description  Server-Sent Events: новые объявления, удаления и новые изображения. С `mine=true` — только события по вашим объявлениям (нужен токен).
+// @Tags         events
+// @Produce      text/event-stream
+// @Security     BearerAuth
+// @Param        mine  query     bool  false  "Только мои объявления"
+// @Success      200   {object}  dto.EventDTO
+// @Failure      400   {object}  dto.ErrEvents400
+// @Failure      401   {object}  dto.ErrEvents401
+// @Failure      500   {object}  dto.ErrEvents500
+// @Router       /api/v1/events [get]
+func (s *StreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	userId, status, err := s.channelUser(r)
+	if err != nil {
+		writeErr(w, status, err.Error())
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeErr(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	sub := s.hub.Subscribe(userId)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-sub.C:
+			if !ok {
+				// хаб закрыл подписку: клиент отстал или сервер останавливается
+				fmt.Fprint(w, "event: close\ndata: {}\n\n")
+				flusher.Flush()
+				return
+			}
+			data, err := json.Marshal(mapper.EventToDTO(e))
+			if err != nil {
+				log.Println("event marshal failed:", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Id, e.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// StreamWS godoc
+// @Summary      Поток событий ленты (WebSocket)
+// @Description  Те же события, что и в SSE, но через WebSocket. С `mine=true` — только события по вашим объявлениям (нужен токен).
+// @Tags         events
+// @Security     BearerAuth
+// @Param        mine  query     bool  false  "Только мои объявления"
+// @Success      101   {object}  dto.EventDTO
+// @Failure      400   {object}  dto.ErrEvents400
+// @Failure      401   {object}  dto.ErrEvents401
+// @Router       /api/v1/events/ws [get]
+func (s *StreamHandler) StreamWS(w http.ResponseWriter, r *http.Request) {
+	userId, status, err := s.channelUser(r)
+	if err != nil {
+		writeErr(w, status, err.Error())
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade сам отвечает клиенту ошибкой
+		return
+	}
+	defer conn.Close()
+
+	sub := s.hub.Subscribe(userId)
+	defer sub.Close()
+
+	// входящие сообщения не нужны, читаем только чтобы заметить закрытие соединения
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case e, ok := <-sub.C:
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "subscription closed"))
+				return
+			}
+			if err := conn.WriteJSON(mapper.EventToDTO(e)); err != nil {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// channelUser — определяет персональный канал: пустая строка означает общую ленту
+func (s *StreamHandler) channelUser(r *http.Request) (string, int, error) {
+	switch r.URL.Query().Get("mine") {
+	case "", "false":
+		return "", http.StatusOK, nil
+	case "true":
+		userId, ok := r.Context().Value("user_id").(string)
+		if !ok || userId == "" {
+			return "", http.StatusUnauthorized, errors.New("unauthorized")
+		}
+		return userId, http.StatusOK, nil
+	default:
+		return "", http.StatusBadRequest, errors.New("mine must be true or false")
+	}
+}
+
+func writeErr(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(dto.ErrResponse{
+		Code:    code,
+		Message: message,
+	})
+}
@@ -0,0 +1,16 @@
+package dto
+
+type ErrResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type ErrTags400 struct {
+	Message string `json:"message" example:"invalid limit: must be an integer"`
+	Code    int    `json:"code" example:"400"`
+}
+
+type ErrTags500 struct {
+	Message string `json:"message" example:"internal server error"`
+	Code    int    `json:"code" example:"500"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+type TagDTO struct {
+	Name  string `json:"name" example:"винтаж"`
+	Count int    `json:"count" example:"42"`
+}
+
+type TagsResponseDTO struct {
+	Tags []TagDTO `json:"tags"`
+}
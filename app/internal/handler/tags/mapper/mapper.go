@@ -0,0 +1,19 @@
+package mapper
+
+import (
+	"market/app/internal/entity"
+	"market/app/internal/handler/tags/dto"
+)
+
+func EntityTagsToDTO(tags []entity.Tag) dto.TagsResponseDTO {
+	var res dto.TagsResponseDTO
+
+	res.Tags = make([]dto.TagDTO, 0, len(tags))
+	for _, tag := range tags {
+		res.Tags = append(res.Tags, dto.TagDTO{
+			Name:  tag.Name,
+			Count: tag.Count,
+		})
+	}
+	return res
+}
@@ -0,0 +1,69 @@
+package tags
+
+import (
+	"encoding/json"
+	"errors"
+	"market/app/internal/apperr"
+	"market/app/internal/handler/tags/dto"
+	"market/app/internal/handler/tags/mapper"
+	"net/http"
+	"strconv"
+)
+
+type TagsHandler struct {
+	tags Tags
+}
+
+func NewTagsHandler(tags Tags) *TagsHandler {
+	return &TagsHandler{tags}
+}
+
+// Suggest godoc
+// @Summary      Подсказки тегов
+// @Description  Теги, начинающиеся с prefix, отсортированные по числу объявлений с ними
+// @Tags         tags
+// @Produce      json
+// @Param        prefix  query     string  false  "Начало тега"
+// @Param        limit   query     int     false  "Количество (по умолчанию 10, максимум 50)"
+// @Success      200  {object}  dto.TagsResponseDTO
+// @Failure      400  {object}  dto.ErrTags400
+// @Failure      500  {object}  dto.ErrTags500
+// @Router       /api/v1/tags/suggest [get]
+func (t *TagsHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var limit int
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: apperr.NewFieldError("limit", "must be an integer").Error(),
+			})
+			return
+		}
+		limit = n
+	}
+
+	res, err := t.tags.Suggest(r.URL.Query().Get("prefix"), limit)
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidQueryParam) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(dto.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			})
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(dto.ErrResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapper.EntityTagsToDTO(res))
+}
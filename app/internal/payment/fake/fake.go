@@ -0,0 +1,85 @@
+package fake
+
+import (
+	"errors"
+	"market/app/internal/utils"
+	"sync"
+)
+
+var (
+	ErrDeclined        = errors.New("payment declined")
+	ErrPaymentNotFound = errors.New("payment not found")
+	ErrInvalidState    = errors.New("payment is not in authorized state")
+)
+
+type state int
+
+const (
+	authorized state = iota
+	captured
+	voided
+)
+
+type payment struct {
+	orderId string
+	amount  float64
+	state   state
+}
+
+// Provider — платёжный провайдер в памяти для локальной разработки.
+// Суммы больше limit отклоняются, чтобы можно было проверить сценарий отказа.
+type Provider struct {
+	mu       sync.Mutex
+	limit    float64
+	payments map[string]*payment
+}
+
+func NewProvider(limit float64) *Provider {
+	return &Provider{
+		limit:    limit,
+		payments: make(map[string]*payment),
+	}
+}
+
+func (p *Provider) Authorize(orderId string, amount float64) (string, error) {
+	if amount <= 0 || (p.limit > 0 && amount > p.limit) {
+		return "", ErrDeclined
+	}
+
+	id, err := utils.GenerateUUID()
+	if err != nil {
+		return "", err
+	}
+	paymentId := "fake_" + id
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.payments[paymentId] = &payment{orderId: orderId, amount: amount, state: authorized}
+	return paymentId, nil
+}
+
+func (p *Provider) Capture(paymentId string) error {
+	return p.finish(paymentId, captured)
+}
+
+func (p *Provider) Void(paymentId string) error {
+	return p.finish(paymentId, voided)
+}
+
+func (p *Provider) finish(paymentId string, to state) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pay, ok := p.payments[paymentId]
+	if !ok {
+		return ErrPaymentNotFound
+	}
+	if pay.state == to {
+		return nil
+	}
+	if pay.state != authorized {
+		return ErrInvalidState
+	}
+	pay.state = to
+	return nil
+}
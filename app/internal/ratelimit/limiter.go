@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter — ограничение частоты действий по ключу со скользящим окном.
+// Хранит время последних limit событий для каждого ключа, поэтому годится
+// для небольших лимитов вроде «20 раз в час», а не для ограничения RPS.
+type Limiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	hits   map[string][]time.Time
+}
+
+func NewLimiter(limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		limit:  limit,
+		window: window,
+		hits:   make(map[string][]time.Time),
+	}
+}
+
+// Allow — учитывает событие для key. Если лимит исчерпан, событие не учитывается
+// и возвращается время, через которое освободится место.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	hits := l.actual(l.hits[key], now)
+
+	if len(hits) >= l.limit {
+		l.hits[key] = hits
+		return false, hits[0].Add(l.window).Sub(now)
+	}
+
+	l.hits[key] = append(hits, now)
+	l.sweep(now)
+	return true, 0
+}
+
+// actual — события, попадающие в окно
+func (l *Limiter) actual(hits []time.Time, now time.Time) []time.Time {
+	i := 0
+	for i < len(hits) && !hits[i].Add(l.window).After(now) {
+		i++
+	}
+	return hits[i:]
+}
+
+// sweep — удаляет ключи без событий в окне, чтобы карта не росла бесконечно
+func (l *Limiter) sweep(now time.Time) {
+	for key, hits := range l.hits {
+		if len(l.actual(hits, now)) == 0 {
+			delete(l.hits, key)
+		}
+	}
+}
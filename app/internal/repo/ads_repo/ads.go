@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"market/app/internal/apperr"
 	"market/app/internal/entity"
 
@@ -12,12 +13,41 @@ import (
 )
 
 type AdDTO struct {
-	Id          string    `db:"id"`
-	Title       string    `db:"title"`
-	Description string    `db:"description"`
-	Price       float64   `db:"price"`
-	CreatedAt   time.Time `db:"created_at"`
-	AuthorId    string    `db:"author_id"`
+	Id          string         `db:"id"`
+	Title       string         `db:"title"`
+	Description string         `db:"description"`
+	Price       float64        `db:"price"`
+	CreatedAt   time.Time      `db:"created_at"`
+	AuthorId    string         `db:"author_id"`
+	PublishAt   sql.NullTime   `db:"publish_at"`
+	Published   bool           `db:"published"`
+	Phone       sql.NullString `db:"contact_phone"`
+	Quantity    int            `db:"quantity"`
+}
+
+const adColumns = `id, title, description, price, created_at, author_id, publish_at, published, contact_phone, quantity`
+
+func (d AdDTO) toEntity() entity.Ad {
+	return entity.Ad{
+		Id:           d.Id,
+		Title:        d.Title,
+		Description:  d.Description,
+		Price:        d.Price,
+		CreatedAt:    d.CreatedAt,
+		AuthorId:     d.AuthorId,
+		PublishAt:    d.PublishAt.Time,
+		Published:    d.Published,
+		ContactPhone: d.Phone.String,
+		Quantity:     d.Quantity,
+	}
+}
+
+func toEntities(tmp []AdDTO) []entity.Ad {
+	ads := make([]entity.Ad, 0, len(tmp))
+	for _, v := range tmp {
+		ads = append(ads, v.toEntity())
+	}
+	return ads
 }
 
 type AdsRepository struct {
@@ -28,68 +58,132 @@ func NewAdsRepository(db *sqlx.DB) *AdsRepository {
 	return &AdsRepository{db}
 }
 
+// Create — сохраняет объявление вместе с тегами в одной транзакции
 func (r *AdsRepository) Create(ad entity.Ad) (entity.Ad, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return entity.Ad{}, err
+	}
+	defer tx.Rollback()
+
 	query := `
-		INSERT INTO ads (id, title, description, price, created_at, author_id)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, title, description, price, created_at, author_id;
-	`
+		INSERT INTO ads (id, title, description, price, created_at, author_id, publish_at, published, contact_phone, quantity)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING ` + adColumns
 
 	var tmp AdDTO
-	err := r.db.Get(&tmp, query,
+	err = tx.Get(&tmp, query,
 		ad.Id,
 		ad.Title,
 		ad.Description,
 		ad.Price,
 		ad.CreatedAt,
 		ad.AuthorId,
+		sql.NullTime{Time: ad.PublishAt, Valid: !ad.PublishAt.IsZero()},
+		ad.Published,
+		sql.NullString{String: ad.ContactPhone, Valid: ad.ContactPhone != ""},
+		ad.Quantity,
 	)
+	if err != nil {
+		return entity.Ad{}, err
+	}
+
+	for _, tag := range ad.Tags {
+		var tagId int64
+		err := tx.Get(&tagId, `
+			INSERT INTO tags (name) VALUES ($1)
+			ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+			RETURNING id
+		`, tag)
+		if err != nil {
+			return entity.Ad{}, err
+		}
+		if _, err := tx.Exec(`INSERT INTO ad_tags (ad_id, tag_id) VALUES ($1, $2)`, ad.Id, tagId); err != nil {
+			return entity.Ad{}, err
+		}
+	}
 
-	savedAd := entity.Ad{
-		Id:          tmp.Id,
-		Title:       tmp.Title,
-		Description: tmp.Description,
-		Price:       tmp.Price,
-		CreatedAt:   tmp.CreatedAt,
-		AuthorId:    tmp.AuthorId,
+	if err := tx.Commit(); err != nil {
+		return entity.Ad{}, err
 	}
-	return savedAd, err
+
+	savedAd := tmp.toEntity()
+	savedAd.Tags = ad.Tags
+	return savedAd, nil
 }
 
 // GetAll — получение всех объявлений с фильтрацией, сортировкой и пагинацией
-func (r *AdsRepository) GetAll(limit, offset int, sortBy, order string, priceMin, priceMax float64) ([]entity.Ad, error) {
+func (r *AdsRepository) GetAll(filter entity.AdsFilter) ([]entity.Ad, error) {
+	limit := filter.Limit
 	if limit == 0 {
 		limit = 10
 	}
 
-	allowedSortFields := map[string]bool{"created_at": true, "price": true}
-	allowedOrder := map[string]bool{"asc": true, "desc": true}
+	allowedSortFields := map[string]bool{"created_at": true, "price": true, "title": true}
 
-	if !allowedSortFields[sortBy] {
-		sortBy = "created_at"
+	orderBy := make([]string, 0, len(filter.Sort)+1)
+	for _, key := range filter.Sort {
+		if !allowedSortFields[key.Field] {
+			continue
+		}
+		direction := "asc"
+		if key.Desc {
+			direction = "desc"
+		}
+		orderBy = append(orderBy, key.Field+" "+direction)
 	}
-	if !allowedOrder[order] {
-		order = "desc"
+	if len(orderBy) == 0 {
+		orderBy = append(orderBy, "created_at desc")
 	}
+	// стабильный порядок страниц при равных значениях ключей
+	orderBy = append(orderBy, "id")
 
 	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
 	query := psql.
-		Select("id", "title", "description", "price", "created_at", "author_id").
+		Select(adColumns).
 		From("ads").
+		Where(squirrel.Eq{"hidden": false}).
 		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		OrderBy(sortBy + " " + order)
+		Offset(uint64(filter.Offset)).
+		OrderBy(orderBy...)
 
-	if priceMin > 0 && priceMax > 0 {
-		query = query.Where(squirrel.And{
-			squirrel.GtOrEq{"price": priceMin},
-			squirrel.LtOrEq{"price": priceMax},
-		})
-	} else if priceMin > 0 {
-		query = query.Where(squirrel.GtOrEq{"price": priceMin})
-	} else if priceMax > 0 {
-		query = query.Where(squirrel.LtOrEq{"price": priceMax})
+	if filter.ViewerId != "" {
+		query = query.Where(squirrel.Or{squirrel.Eq{"published": true}, squirrel.Eq{"author_id": filter.ViewerId}})
+	} else {
+		query = query.Where(squirrel.Eq{"published": true})
+	}
+
+	if filter.PriceMin > 0 {
+		query = query.Where(squirrel.GtOrEq{"price": filter.PriceMin})
+	}
+	if filter.PriceMax > 0 {
+		query = query.Where(squirrel.LtOrEq{"price": filter.PriceMax})
+	}
+	if filter.AuthorId != "" {
+		query = query.Where(squirrel.Eq{"author_id": filter.AuthorId})
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query = query.Where(squirrel.GtOrEq{"created_at": filter.CreatedAfter})
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where(squirrel.Lt{"created_at": filter.CreatedBefore})
+	}
+	if len(filter.Tags) > 0 {
+		if filter.TagsMatchAll {
+			query = query.Where(`(SELECT COUNT(*) FROM ad_tags JOIN tags ON tags.id = ad_tags.tag_id
+				WHERE ad_tags.ad_id = ads.id AND tags.name = ANY(?)) = ?`, pq.Array(filter.Tags), len(filter.Tags))
+		} else {
+			query = query.Where(`EXISTS (SELECT 1 FROM ad_tags JOIN tags ON tags.id = ad_tags.tag_id
+				WHERE ad_tags.ad_id = ads.id AND tags.name = ANY(?))`, pq.Array(filter.Tags))
+		}
+	}
+	if filter.HasImages != nil {
+		hasImages := "EXISTS (SELECT 1 FROM ad_images WHERE ad_images.ad_id = ads.id)"
+		if !*filter.HasImages {
+			hasImages = "NOT " + hasImages
+		}
+		query = query.Where(hasImages)
 	}
 
 	sqlQuery, args, err := query.ToSql()
@@ -106,25 +200,10 @@ func (r *AdsRepository) GetAll(limit, offset int, sortBy, order string, priceMin
 		return nil, apperr.ErrAdsNotFound
 	}
 
-	ads := make([]entity.Ad, 0, len(tmp))
-	for _, v := range tmp {
-		ads = append(ads, entity.Ad{
-			Id:          v.Id,
-			Title:       v.Title,
-			Description: v.Description,
-			Price:       v.Price,
-			CreatedAt:   v.CreatedAt,
-			AuthorId:    v.AuthorId,
-		})
-	}
-	return ads, nil
+	return toEntities(tmp), nil
 }
 func (r *AdsRepository) GetById(adId string) (entity.Ad, error) {
-	query := `
-		SELECT id, title, description, price, created_at, author_id
-		FROM ads
-		WHERE id = $1
-	`
+	query := `SELECT ` + adColumns + ` FROM ads WHERE id = $1`
 	var tmp AdDTO
 	err := r.db.Get(&tmp, query, adId)
 	if err != nil {
@@ -134,33 +213,196 @@ func (r *AdsRepository) GetById(adId string) (entity.Ad, error) {
 		return entity.Ad{}, err
 	}
 
-	ad := entity.Ad{
-		Id:          tmp.Id,
-		Title:       tmp.Title,
-		Description: tmp.Description,
-		Price:       tmp.Price,
-		CreatedAt:   tmp.CreatedAt,
-		AuthorId:    tmp.AuthorId,
-	}
-	return ad, nil
+	return tmp.toEntity(), nil
 }
 
-// Delete — удаляет объявление, если принадлежит userId
+// Delete — удаляет объявление; изображения уходят каскадом, поэтому в той же
+// транзакции снимаются их ссылки на общие файлы в image_blobs. Файлы без
+// ссылок остаются в хранилище до сборщика мусора.
 func (r *AdsRepository) Delete(userId, adId string) error {
-	query := `DELETE FROM ads WHERE id = $1 AND author_id = $2`
-
-	result, err := r.db.Exec(query, adId, userId)
+	tx, err := r.db.Beginx()
 	if err != nil {
 		return err
 	}
-	rowsAffected, err := result.RowsAffected()
+	defer tx.Rollback()
+
+	// блокировка объявления не даёт добавить изображение между подсчётом ссылок и удалением
+	var id string
+	err = tx.Get(&id, `SELECT id FROM ads WHERE id = $1 AND author_id = $2 FOR UPDATE`, adId, userId)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperr.ErrAdsNotFound
+		}
 		return err
 	}
-	if rowsAffected == 0 {
-		return apperr.ErrAdsNotFound
+
+	releaseQuery := `
+		UPDATE image_blobs b SET refs = b.refs - i.n
+		FROM (
+			SELECT hash, COUNT(*) AS n FROM ad_images
+			WHERE ad_id = $1 AND hash IS NOT NULL
+			GROUP BY hash
+		) i
+		WHERE b.hash = i.hash
+	`
+	if _, err := tx.Exec(releaseQuery, adId); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM ads WHERE id = $1`, adId); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetSimilar — объявления других авторов, похожие на ad: триграммная похожесть заголовка
+// и описания, близость цены и свежесть. Веса подобраны так, чтобы текст был главным.
+func (r *AdsRepository) GetSimilar(ad entity.Ad, limit int) ([]entity.Ad, error) {
+	query := `
+		SELECT ` + adColumns + `
+		FROM ads
+		WHERE id <> $1
+		  AND author_id <> $2
+		  AND hidden = false
+		  AND published = true
+		  AND quantity > 0
+		  AND (title % $3 OR similarity(description, $4) > 0.1)
+		ORDER BY
+		    0.6 * similarity(title, $3)
+		  + 0.2 * similarity(description, $4)
+		  + 0.1 * (1 - LEAST(ABS(price - $5) / GREATEST($5, 1), 1))
+		  + 0.1 * EXP(-EXTRACT(EPOCH FROM (now() - created_at)) / 2592000.0) DESC
+		LIMIT $6
+	`
+
+	var tmp []AdDTO
+	err := r.db.Select(&tmp, query, ad.Id, ad.AuthorId, ad.Title, ad.Description, ad.Price, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEntities(tmp), nil
+}
+
+// PublishDue — публикует объявления, у которых наступило время публикации.
+// SKIP LOCKED позволяет нескольким экземплярам приложения работать одновременно:
+// каждую строку переключает ровно один из них, и только он получает её в RETURNING.
+// created_at переносится на время публикации, чтобы объявление попало в начало ленты.
+func (r *AdsRepository) PublishDue(now time.Time, limit int) ([]entity.Ad, error) {
+	query := `
+		UPDATE ads SET published = true, created_at = GREATEST(created_at, publish_at)
+		WHERE id IN (
+			SELECT id FROM ads
+			WHERE published = false AND publish_at <= $1
+			ORDER BY publish_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + adColumns
+
+	var tmp []AdDTO
+	if err := r.db.Select(&tmp, query, now, limit); err != nil {
+		return nil, err
+	}
+	return toEntities(tmp), nil
+}
+
+// GetTags — теги объявления в порядке добавления
+func (r *AdsRepository) GetTags(adId string) ([]string, error) {
+	query := `
+		SELECT tags.name
+		FROM ad_tags
+		JOIN tags ON tags.id = ad_tags.tag_id
+		WHERE ad_tags.ad_id = $1
+		ORDER BY ad_tags.created_at, tags.name
+	`
+	tags := make([]string, 0)
+	if err := r.db.Select(&tags, query, adId); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// SellUnits — списывает units единиц товара одним UPDATE: условие quantity >= units
+// проверяется под блокировкой строки, поэтому параллельные продажи не уводят остаток в минус.
+// Если единиц не хватает, возвращает apperr.ErrNotEnoughStock.
+func (r *AdsRepository) SellUnits(adId string, units int) (entity.Ad, error) {
+	query := `
+		UPDATE ads SET quantity = quantity - $1
+		WHERE id = $2 AND quantity >= $1
+		RETURNING ` + adColumns
+
+	var tmp AdDTO
+	if err := r.db.Get(&tmp, query, units, adId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Ad{}, apperr.ErrNotEnoughStock
+		}
+		return entity.Ad{}, err
 	}
-	return nil
+	return tmp.toEntity(), nil
+}
+
+// GetContact — телефон продавца для объявления: из объявления, иначе из профиля автора
+func (r *AdsRepository) GetContact(adId string) (entity.AdContact, error) {
+	query := `
+		SELECT ads.id, ads.author_id, ads.published,
+		       COALESCE(ads.contact_phone, users.phone, '') AS phone
+		FROM ads
+		JOIN users ON users.id = ads.author_id
+		WHERE ads.id = $1
+	`
+
+	var tmp struct {
+		AdId      string `db:"id"`
+		AuthorId  string `db:"author_id"`
+		Published bool   `db:"published"`
+		Phone     string `db:"phone"`
+	}
+	if err := r.db.Get(&tmp, query, adId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.AdContact{}, apperr.ErrAdsNotFound
+		}
+		return entity.AdContact{}, err
+	}
+
+	return entity.AdContact{
+		AdId:      tmp.AdId,
+		AuthorId:  tmp.AuthorId,
+		Phone:     tmp.Phone,
+		Published: tmp.Published,
+	}, nil
+}
+
+func (r *AdsRepository) SaveReveal(adId, userId string, at time.Time) error {
+	query := `INSERT INTO contact_reveals (ad_id, user_id, created_at) VALUES ($1, $2, $3)`
+	_, err := r.db.Exec(query, adId, userId, at)
+	return err
+}
+
+// GetRevealStats — сколько раз и сколько разных пользователей открывали телефон по объявлению
+func (r *AdsRepository) GetRevealStats(adId string) (entity.RevealStats, error) {
+	query := `
+		SELECT COUNT(*) AS total,
+		       COUNT(DISTINCT user_id) AS unique_users,
+		       MAX(created_at) AS last_at
+		FROM contact_reveals
+		WHERE ad_id = $1
+	`
+
+	var tmp struct {
+		Total       int          `db:"total"`
+		UniqueUsers int          `db:"unique_users"`
+		LastAt      sql.NullTime `db:"last_at"`
+	}
+	if err := r.db.Get(&tmp, query, adId); err != nil {
+		return entity.RevealStats{}, err
+	}
+
+	return entity.RevealStats{
+		Total:       tmp.Total,
+		UniqueUsers: tmp.UniqueUsers,
+		LastAt:      tmp.LastAt.Time,
+	}, nil
 }
 
 // GetAuthorName — получить имя автора по userId
@@ -176,3 +418,47 @@ func (r *AdsRepository) GetAuthorName(userId string) (string, error) {
 	}
 	return username, nil
 }
+
+// GetAuthors — пользователи по списку id одним запросом
+func (r *AdsRepository) GetAuthors(ids []string) ([]entity.User, error) {
+	query := `SELECT id, username FROM users WHERE id = ANY($1)`
+
+	var tmp []struct {
+		Id       string `db:"id"`
+		Username string `db:"username"`
+	}
+	if err := r.db.Select(&tmp, query, pq.Array(ids)); err != nil {
+		return nil, err
+	}
+
+	users := make([]entity.User, 0, len(tmp))
+	for _, v := range tmp {
+		users = append(users, entity.User{Id: v.Id, Username: v.Username})
+	}
+	return users, nil
+}
+
+// GetTagsByAds — теги нескольких объявлений одним запросом, порядок как в GetTags
+func (r *AdsRepository) GetTagsByAds(adIds []string) (map[string][]string, error) {
+	query := `
+		SELECT ad_tags.ad_id, tags.name
+		FROM ad_tags
+		JOIN tags ON tags.id = ad_tags.tag_id
+		WHERE ad_tags.ad_id = ANY($1)
+		ORDER BY ad_tags.created_at, tags.name
+	`
+
+	var tmp []struct {
+		AdId string `db:"ad_id"`
+		Name string `db:"name"`
+	}
+	if err := r.db.Select(&tmp, query, pq.Array(adIds)); err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string][]string, len(adIds))
+	for _, v := range tmp {
+		tags[v.AdId] = append(tags[v.AdId], v.Name)
+	}
+	return tags, nil
+}
@@ -2,8 +2,11 @@ package img_repo
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"market/app/internal/apperr"
 	"market/app/internal/entity"
 	"time"
@@ -17,57 +20,346 @@ func NewImgRepo(db *sqlx.DB) *ImgRepo {
 	return &ImgRepo{db}
 }
 
+// imageColumns — private берётся из объявления, подзапрос работает и в RETURNING
+const imageColumns = `id, ad_id, image_url, created_at, variants, position, size_bytes, hash,
+	(SELECT images_private FROM ads WHERE ads.id = ad_images.ad_id) AS private`
+
 type dto struct {
-	Id        string    `db:"id"`
-	AdId      string    `db:"ad_id"`
-	ImageUrl  string    `db:"image_url"`
-	CreatedAt time.Time `db:"created_at"`
+	Id        string         `db:"id"`
+	AdId      string         `db:"ad_id"`
+	ImageUrl  string         `db:"image_url"`
+	CreatedAt time.Time      `db:"created_at"`
+	Variants  []byte         `db:"variants"`
+	Position  int            `db:"position"`
+	SizeBytes int64          `db:"size_bytes"`
+	Hash      sql.NullString `db:"hash"`
+	Private   bool           `db:"private"`
 }
 
-func (i *ImgRepo) Create(img entity.AdImage) (entity.AdImage, error) {
-	query := `INSERT INTO ad_images (id,ad_id,image_url,created_at) VALUES ($1,$2,$3,$4) RETURNING id,ad_id,image_url,created_at`
+func toEntity(v dto) (entity.AdImage, error) {
+	res := entity.AdImage{
+		Id:        v.Id,
+		AdId:      v.AdId,
+		ImageURL:  v.ImageUrl,
+		CreatedAt: v.CreatedAt,
+		Position:  v.Position,
+		Size:      v.SizeBytes,
+		Hash:      v.Hash.String,
+		Private:   v.Private,
+	}
+	if len(v.Variants) > 0 {
+		if err := json.Unmarshal(v.Variants, &res.Variants); err != nil {
+			return entity.AdImage{}, fmt.Errorf("decode image variants failed: %w", err)
+		}
+	}
+	return res, nil
+}
 
-	tmp := struct {
-		Id        string    `db:"id"`
-		AdId      string    `db:"ad_id"`
-		ImageUrl  string    `db:"image_url"`
-		CreatedAt time.Time `db:"created_at"`
-	}{}
+func toEntities(tmp []dto) ([]entity.AdImage, error) {
+	images := make([]entity.AdImage, 0, len(tmp))
+	for _, v := range tmp {
+		img, err := toEntity(v)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, img)
+	}
+	return images, nil
+}
 
-	err := i.db.Get(&tmp, query, img.Id, img.AdId, img.ImageURL, img.CreatedAt)
+// Create — сохраняет изображение, если после него у объявления будет не больше
+// maxPerAd изображений, а у автора — не больше quotaBytes байт (0 — без ограничения).
+// Строка автора блокируется на время транзакции, поэтому параллельные загрузки
+// одного пользователя проверяются по очереди и не превышают лимиты вдвоём.
+//
+// Файлы общие для всех изображений с тем же img.Hash. store (запись файлов)
+// вызывается внутри транзакции, только если ссылок на hash ещё не было; строка
+// image_blobs заблокирована, так что Delete не удалит файлы одновременно с записью.
+// Если после store что-то не удалось, вызывается discard.
+func (i *ImgRepo) Create(img entity.AdImage, maxPerAd int, quotaBytes int64, store func() error, discard func()) (entity.AdImage, error) {
+	variants, err := json.Marshal(img.Variants)
 	if err != nil {
 		return entity.AdImage{}, err
 	}
-	res := entity.AdImage{
-		Id:        tmp.Id,
-		AdId:      tmp.AdId,
-		ImageURL:  tmp.ImageUrl,
-		CreatedAt: tmp.CreatedAt,
+	if img.Variants == nil {
+		variants = []byte("{}")
 	}
-	return res, nil
+
+	tx, err := i.db.Beginx()
+	if err != nil {
+		return entity.AdImage{}, err
+	}
+	defer tx.Rollback()
+
+	// объявление блокируется сразу, до image_blobs: удаление объявления берёт
+	// блокировки в том же порядке, и взаимной блокировки не возникает
+	var authorId string
+	err = tx.Get(&authorId, `
+		SELECT u.id FROM users u
+		JOIN ads a ON a.author_id = u.id
+		WHERE a.id = $1
+		FOR UPDATE OF u FOR KEY SHARE OF a
+	`, img.AdId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.AdImage{}, apperr.ErrAddNotFound
+		}
+		return entity.AdImage{}, err
+	}
+
+	var usage struct {
+		AdImages  int   `db:"ad_images"`
+		UsedBytes int64 `db:"used_bytes"`
+	}
+	err = tx.Get(&usage, `
+		SELECT COUNT(*) FILTER (WHERE i.ad_id = $2) AS ad_images,
+		       COALESCE(SUM(i.size_bytes), 0) AS used_bytes
+		FROM ad_images i
+		JOIN ads a ON a.id = i.ad_id
+		WHERE a.author_id = $1
+	`, authorId, img.AdId)
+	if err != nil {
+		return entity.AdImage{}, err
+	}
+	if maxPerAd > 0 && usage.AdImages >= maxPerAd {
+		return entity.AdImage{}, apperr.ErrImageLimitReached
+	}
+	if quotaBytes > 0 && usage.UsedBytes+img.Size > quotaBytes {
+		return entity.AdImage{}, apperr.ErrStorageQuota
+	}
+
+	var refs int
+	err = tx.Get(&refs, `
+		INSERT INTO image_blobs (hash, refs) VALUES ($1, 1)
+		ON CONFLICT (hash) DO UPDATE SET refs = image_blobs.refs + 1
+		RETURNING refs
+	`, img.Hash)
+	if err != nil {
+		return entity.AdImage{}, err
+	}
+	stored, committed := false, false
+	if refs == 1 {
+		if err := store(); err != nil {
+			return entity.AdImage{}, err
+		}
+		stored = true
+	}
+	// выполняется до Rollback, пока строка image_blobs ещё заблокирована
+	defer func() {
+		if stored && !committed {
+			discard()
+		}
+	}()
+
+	// новое изображение встаёт в конец списка
+	query := `
+		INSERT INTO ad_images (id, ad_id, image_url, created_at, variants, position, size_bytes, hash)
+		VALUES ($1, $2, $3, $4, $5, (SELECT COALESCE(MAX(position) + 1, 0) FROM ad_images WHERE ad_id = $2), $6, $7)
+		RETURNING ` + imageColumns
+
+	var tmp dto
+	err = tx.Get(&tmp, query, img.Id, img.AdId, img.ImageURL, img.CreatedAt, string(variants), img.Size, img.Hash)
+	if err != nil {
+		return entity.AdImage{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return entity.AdImage{}, err
+	}
+	committed = true
+	return toEntity(tmp)
 }
 
-func (i *ImgRepo) Delete(imgID string) error {
-	query := `DELETE FROM ad_images WHERE id=$1`
+// StorageUsage — сколько байт и изображений сейчас у пользователя
+func (i *ImgRepo) StorageUsage(userId string) (int64, int, error) {
+	var usage struct {
+		UsedBytes int64 `db:"used_bytes"`
+		Images    int   `db:"images"`
+	}
+	query := `
+		SELECT COALESCE(SUM(i.size_bytes), 0) AS used_bytes, COUNT(*) AS images
+		FROM ad_images i
+		JOIN ads a ON a.id = i.ad_id
+		WHERE a.author_id = $1
+	`
+	if err := i.db.Get(&usage, query, userId); err != nil {
+		return 0, 0, err
+	}
+	return usage.UsedBytes, usage.Images, nil
+}
 
-	_, err := i.db.Exec(query, imgID)
+// Delete — удаляет запись об изображении и в той же транзакции вызывает cleanup
+// (удаление файла). Если cleanup вернул ошибку, запись остаётся на месте.
+// Общие файлы удаляются только вместе с последней ссылкой на их hash.
+func (i *ImgRepo) Delete(imgID string, cleanup func() error) error {
+	tx, err := i.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// как и в Create, сначала объявление, потом image_blobs
+	_, err = tx.Exec(`SELECT 1 FROM ads WHERE id = (SELECT ad_id FROM ad_images WHERE id = $1) FOR KEY SHARE`, imgID)
+	if err != nil {
+		return err
+	}
+
+	var hash sql.NullString
+	err = tx.Get(&hash, `DELETE FROM ad_images WHERE id = $1 RETURNING hash`, imgID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return apperr.ErrImgNotFound
 		}
 		return err
 	}
+
+	// у изображений, загруженных до дедупликации, hash нет и файлы только свои
+	if hash.Valid {
+		var refs int
+		err = tx.Get(&refs, `UPDATE image_blobs SET refs = refs - 1 WHERE hash = $1 RETURNING refs`, hash.String)
+		if err != nil {
+			return err
+		}
+		if refs > 0 {
+			return tx.Commit()
+		}
+		if _, err := tx.Exec(`DELETE FROM image_blobs WHERE hash = $1`, hash.String); err != nil {
+			return err
+		}
+	}
+
+	if cleanup != nil {
+		if err := cleanup(); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// LegacyImages — изображения, загруженные до дедупликации: их файлы названы
+// не по hash, и ссылки на них видны только по URL
+func (i *ImgRepo) LegacyImages() ([]entity.AdImage, error) {
+	query := `SELECT ` + imageColumns + ` FROM ad_images WHERE hash IS NULL`
+
+	var tmp []dto
+	if err := i.db.Select(&tmp, query); err != nil {
+		return nil, err
+	}
+	return toEntities(tmp)
+}
+
+// BlobReferenced — ссылается ли что-нибудь на файлы с таким hash
+func (i *ImgRepo) BlobReferenced(hash string) (bool, error) {
+	var referenced bool
+	query := `
+		SELECT EXISTS (SELECT 1 FROM image_blobs WHERE hash = $1 AND refs > 0)
+		    OR EXISTS (SELECT 1 FROM ad_images WHERE hash = $1)
+	`
+	if err := i.db.Get(&referenced, query, hash); err != nil {
+		return false, err
+	}
+	return referenced, nil
+}
+
+// ReleaseBlob — если на hash никто не ссылается, вызывает remove (удаление файлов)
+// и убирает строку image_blobs; false — файлы ещё нужны. Строка image_blobs
+// создаётся или блокируется на время транзакции, поэтому загрузка того же
+// содержимого ждёт её окончания и запишет файлы заново.
+func (i *ImgRepo) ReleaseBlob(hash string, remove func() error) (bool, error) {
+	tx, err := i.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var refs int
+	err = tx.Get(&refs, `
+		INSERT INTO image_blobs (hash, refs) VALUES ($1, 0)
+		ON CONFLICT (hash) DO UPDATE SET refs = image_blobs.refs
+		RETURNING refs
+	`, hash)
+	if err != nil {
+		return false, err
+	}
+	if refs > 0 {
+		return false, nil
+	}
+	// refs разошёлся с ad_images — файлы не трогаем
+	var used bool
+	if err := tx.Get(&used, `SELECT EXISTS (SELECT 1 FROM ad_images WHERE hash = $1)`, hash); err != nil {
+		return false, err
+	}
+	if used {
+		return false, nil
+	}
+
+	if err := remove(); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(`DELETE FROM image_blobs WHERE hash = $1`, hash); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetImagesPrivate — делает изображения объявления закрытыми или открытыми
+func (i *ImgRepo) SetImagesPrivate(adId string, private bool) error {
+	res, err := i.db.Exec(`UPDATE ads SET images_private = $2 WHERE id = $1`, adId, private)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return apperr.ErrAddNotFound
+	}
 	return nil
 }
 
+// HasPrivateImages — есть ли объявления с закрытыми изображениями
+func (i *ImgRepo) HasPrivateImages() (bool, error) {
+	var exists bool
+	if err := i.db.Get(&exists, `SELECT EXISTS (SELECT 1 FROM ads WHERE images_private)`); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// BlobPrivate — нужен ли для файла key подписанный URL: да, если все изображения,
+// которые на него ссылаются, закрытые. Файлы ищутся по hash, а у изображений,
+// загруженных до дедупликации, — по окончанию URL.
+func (i *ImgRepo) BlobPrivate(key, hash string) (bool, error) {
+	query := `
+		SELECT COALESCE(bool_and(a.images_private), false)
+		FROM ad_images i
+		JOIN ads a ON a.id = i.ad_id
+		WHERE i.hash = $2
+		   OR (i.hash IS NULL AND (
+		       right(i.image_url, length($1) + 1) = '/' || $1
+		       OR EXISTS (
+		           SELECT 1 FROM jsonb_each_text(i.variants) v
+		           WHERE right(v.value, length($1) + 1) = '/' || $1
+		       )
+		   ))
+	`
+	var private bool
+	if err := i.db.Get(&private, query, key, hash); err != nil {
+		return false, err
+	}
+	return private, nil
+}
+
 func (i *ImgRepo) GetImages(adId string) ([]entity.AdImage, error) {
 	query := `
-		SELECT id, ad_id, image_url, created_at
+		SELECT ` + imageColumns + `
 		FROM ad_images
 		WHERE ad_id = $1
-		ORDER BY created_at;
+		ORDER BY position, created_at;
 	`
-	var images []entity.AdImage
 
 	var tmp []dto
 
@@ -78,40 +370,103 @@ func (i *ImgRepo) GetImages(adId string) ([]entity.AdImage, error) {
 		}
 		return nil, err
 	}
+	return toEntities(tmp)
+}
 
-	for _, v := range tmp {
-		i := entity.AdImage{
-			Id:        v.Id,
-			AdId:      v.AdId,
-			ImageURL:  v.ImageUrl,
-			CreatedAt: v.CreatedAt,
+// GetImagesByAds — изображения нескольких объявлений одним запросом
+func (i *ImgRepo) GetImagesByAds(adIds []string) ([]entity.AdImage, error) {
+	query := `
+		SELECT ` + imageColumns + `
+		FROM ad_images
+		WHERE ad_id = ANY($1)
+		ORDER BY position, created_at
+	`
+
+	var tmp []dto
+	if err := i.db.Select(&tmp, query, pq.Array(adIds)); err != nil {
+		return nil, err
+	}
+	return toEntities(tmp)
+}
+
+// Reorder — проставляет позиции изображений объявления в порядке imgIds.
+// imgIds должен содержать каждое изображение объявления ровно один раз,
+// иначе apperr.ErrInvalidImageOrder; проверка и обновление идут в одной транзакции.
+func (i *ImgRepo) Reorder(adId string, imgIds []string) error {
+	tx, err := i.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current []string
+	err = tx.Select(&current, `SELECT id FROM ad_images WHERE ad_id = $1 FOR UPDATE`, adId)
+	if err != nil {
+		return err
+	}
+
+	if len(current) != len(imgIds) {
+		return apperr.ErrInvalidImageOrder
+	}
+	known := make(map[string]bool, len(current))
+	for _, id := range current {
+		known[id] = true
+	}
+	for _, id := range imgIds {
+		if !known[id] {
+			return apperr.ErrInvalidImageOrder
 		}
-		images = append(images, i)
+		// повтор id тоже ошибка: тогда какое-то изображение осталось бы без места
+		delete(known, id)
 	}
-	return images, nil
+
+	query := `
+		UPDATE ad_images SET position = t.ord - 1
+		FROM unnest($1::uuid[]) WITH ORDINALITY AS t(id, ord)
+		WHERE ad_images.id = t.id
+	`
+	if _, err := tx.Exec(query, pq.Array(imgIds)); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (i *ImgRepo) Exists(adId string) (bool, error) {
-	var exists bool
-	query := `SELECT EXISTS (SELECT 1 FROM ads WHERE id = $1 LIMIT 1)`
+// GetAdState — автор объявления и опубликовано ли оно
+func (i *ImgRepo) GetAdState(adId string) (string, bool, error) {
+	var res struct {
+		AuthorId  string `db:"author_id"`
+		Published bool   `db:"published"`
+	}
+	query := `SELECT author_id, published FROM ads WHERE id = $1`
 
-	err := i.db.Get(&exists, query, adId)
+	err := i.db.Get(&res, query, adId)
 	if err != nil {
-		return false, err
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, apperr.ErrAddNotFound
+		}
+		return "", false, err
 	}
-	return exists, nil
+	return res.AuthorId, res.Published, nil
 }
 
-func (i *ImgRepo) GetImageById(id string) (entity.AdImage, error) {
-	query := `SELECT id, ad_id, image_url, created_at FROM ad_images WHERE id = $1`
+func (i *ImgRepo) GetAdAuthorId(adId string) (string, error) {
+	var authorId string
+	query := `SELECT author_id FROM ads WHERE id = $1`
+
+	err := i.db.Get(&authorId, query, adId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", apperr.ErrAddNotFound
+		}
+		return "", err
+	}
+	return authorId, nil
+}
 
-	tmp := struct {
-		Id        string    `db:"id"`
-		AdId      string    `db:"ad_id"`
-		ImageUrl  string    `db:"image_url"`
-		CreatedAt time.Time `db:"created_at"`
-	}{}
+func (i *ImgRepo) GetImageById(id string) (entity.AdImage, error) {
+	query := `SELECT ` + imageColumns + ` FROM ad_images WHERE id = $1`
 
+	var tmp dto
 	err := i.db.Get(&tmp, query, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -119,12 +474,5 @@ func (i *ImgRepo) GetImageById(id string) (entity.AdImage, error) {
 		}
 		return entity.AdImage{}, err
 	}
-
-	res := entity.AdImage{
-		Id:        tmp.Id,
-		AdId:      tmp.AdId,
-		ImageURL:  tmp.ImageUrl,
-		CreatedAt: tmp.CreatedAt,
-	}
-	return res, nil
+	return toEntity(tmp)
 }
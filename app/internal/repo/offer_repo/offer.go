@@ -0,0 +1,269 @@
+package offer_repo
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"time"
+)
+
+type OfferDTO struct {
+	Id             string          `db:"id"`
+	AdId           string          `db:"ad_id"`
+	BuyerId        string          `db:"buyer_id"`
+	Amount         float64         `db:"amount"`
+	Message        string          `db:"message"`
+	CounterAmount  sql.NullFloat64 `db:"counter_amount"`
+	CounterMessage string          `db:"counter_message"`
+	HideAd         bool            `db:"hide_ad"`
+	Status         string          `db:"status"`
+	CreatedAt      time.Time       `db:"created_at"`
+	UpdatedAt      time.Time       `db:"updated_at"`
+	ExpiresAt      time.Time       `db:"expires_at"`
+}
+
+const offerColumns = `id, ad_id, buyer_id, amount, message, counter_amount, counter_message, hide_ad, status, created_at, updated_at, expires_at`
+
+type OfferRepo struct {
+	db *sqlx.DB
+}
+
+func NewOfferRepo(db *sqlx.DB) *OfferRepo {
+	return &OfferRepo{db}
+}
+
+// Create — сохраняет предложение, если объявление опубликовано и ещё принимает
+// предложения: не скрыто и не имеет принятого. Строка объявления блокируется FOR SHARE,
+// поэтому создание не проходит параллельно с Accept, который держит FOR UPDATE.
+func (r *OfferRepo) Create(offer entity.Offer) (entity.Offer, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return entity.Offer{}, err
+	}
+	defer tx.Rollback()
+
+	var ad struct {
+		Hidden    bool `db:"hidden"`
+		Published bool `db:"published"`
+	}
+	err = tx.Get(&ad, `SELECT hidden, published FROM ads WHERE id = $1 FOR SHARE`, offer.AdId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Offer{}, apperr.ErrAdsNotFound
+		}
+		return entity.Offer{}, err
+	}
+	// покупатель не автор, а неопубликованное объявление видит только автор
+	if !ad.Published {
+		return entity.Offer{}, apperr.ErrAdsNotFound
+	}
+	if ad.Hidden {
+		return entity.Offer{}, apperr.ErrOfferAdClosed
+	}
+
+	var accepted bool
+	err = tx.Get(&accepted, `SELECT EXISTS (SELECT 1 FROM offers WHERE ad_id = $1 AND status = $2)`,
+		offer.AdId, entity.OfferAccepted)
+	if err != nil {
+		return entity.Offer{}, err
+	}
+	if accepted {
+		return entity.Offer{}, apperr.ErrOfferAlreadyAccepted
+	}
+
+	query := `
+		INSERT INTO offers (id, ad_id, buyer_id, amount, message, status, created_at, updated_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + offerColumns
+
+	var tmp OfferDTO
+	err = tx.Get(&tmp, query,
+		offer.Id,
+		offer.AdId,
+		offer.BuyerId,
+		offer.Amount,
+		offer.Message,
+		offer.Status,
+		offer.CreatedAt,
+		offer.UpdatedAt,
+		offer.ExpiresAt,
+	)
+	if err != nil {
+		return entity.Offer{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.Offer{}, err
+	}
+	return toEntity(tmp), nil
+}
+
+func (r *OfferRepo) GetById(id string) (entity.Offer, error) {
+	query := `SELECT ` + offerColumns + ` FROM offers WHERE id = $1`
+
+	var tmp OfferDTO
+	err := r.db.Get(&tmp, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Offer{}, apperr.ErrOfferNotFound
+		}
+		return entity.Offer{}, err
+	}
+	return toEntity(tmp), nil
+}
+
+// GetByAd — все предложения по объявлению; если buyerId не пустой — только предложения этого покупателя.
+// Открытые предложения с истёкшим сроком отдаются как expired, даже если ExpireOpen их ещё не перевёл.
+func (r *OfferRepo) GetByAd(adId, buyerId string, now time.Time) ([]entity.Offer, error) {
+	query := `
+		SELECT id, ad_id, buyer_id, amount, message, counter_amount, counter_message, hide_ad,
+		       CASE WHEN status IN ($3, $4) AND expires_at <= $5 THEN $6 ELSE status END AS status,
+		       created_at, updated_at, expires_at
+		FROM offers
+		WHERE ad_id = $1 AND ($2 = '' OR buyer_id::text = $2)
+		ORDER BY created_at DESC
+	`
+
+	var tmp []OfferDTO
+	err := r.db.Select(&tmp, query, adId, buyerId, entity.OfferPending, entity.OfferCountered, now, entity.OfferExpired)
+	if err != nil {
+		return nil, err
+	}
+
+	offers := make([]entity.Offer, 0, len(tmp))
+	for _, v := range tmp {
+		offers = append(offers, toEntity(v))
+	}
+	return offers, nil
+}
+
+// ExpireOpen — переводит просроченные открытые предложения по объявлению в expired
+func (r *OfferRepo) ExpireOpen(adId string, now time.Time) error {
+	query := `
+		UPDATE offers SET status = $1, updated_at = $2
+		WHERE ad_id = $3 AND status IN ($4, $5) AND expires_at <= $2
+	`
+	_, err := r.db.Exec(query, entity.OfferExpired, now, adId, entity.OfferPending, entity.OfferCountered)
+	return err
+}
+
+// UpdateStatus — сохраняет переход состояния, если предложение всё ещё в статусе from
+func (r *OfferRepo) UpdateStatus(offer entity.Offer, from entity.OfferStatus) (entity.Offer, error) {
+	query := `
+		UPDATE offers
+		SET status = $1, counter_amount = $2, counter_message = $3, hide_ad = $4, updated_at = $5, expires_at = $6
+		WHERE id = $7 AND status = $8
+		RETURNING ` + offerColumns
+
+	var tmp OfferDTO
+	err := r.db.Get(&tmp, query,
+		offer.Status,
+		nullAmount(offer.CounterAmount),
+		offer.CounterMessage,
+		offer.HideAd,
+		offer.UpdatedAt,
+		offer.ExpiresAt,
+		offer.Id,
+		from,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Offer{}, apperr.ErrOfferTransition
+		}
+		return entity.Offer{}, err
+	}
+	return toEntity(tmp), nil
+}
+
+// Accept — принимает предложение и отклоняет остальные открытые предложения по объявлению.
+// Всё выполняется в одной транзакции под блокировкой строки объявления.
+func (r *OfferRepo) Accept(offer entity.Offer, from entity.OfferStatus) (entity.Offer, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return entity.Offer{}, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT id FROM ads WHERE id = $1 FOR UPDATE`, offer.AdId); err != nil {
+		return entity.Offer{}, err
+	}
+
+	var accepted bool
+	err = tx.Get(&accepted, `SELECT EXISTS (SELECT 1 FROM offers WHERE ad_id = $1 AND status = $2)`,
+		offer.AdId, entity.OfferAccepted)
+	if err != nil {
+		return entity.Offer{}, err
+	}
+	if accepted {
+		return entity.Offer{}, apperr.ErrOfferAlreadyAccepted
+	}
+
+	query := `
+		UPDATE offers SET status = $1, hide_ad = $2, updated_at = $3
+		WHERE id = $4 AND status = $5
+		RETURNING ` + offerColumns
+
+	var tmp OfferDTO
+	err = tx.Get(&tmp, query, entity.OfferAccepted, offer.HideAd, offer.UpdatedAt, offer.Id, from)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Offer{}, apperr.ErrOfferTransition
+		}
+		return entity.Offer{}, err
+	}
+
+	// остальные открытые предложения закрываются: просроченные — как expired, прочие — rejected
+	_, err = tx.Exec(`
+		UPDATE offers SET status = CASE WHEN expires_at <= $2 THEN $1 ELSE $3 END, updated_at = $2
+		WHERE ad_id = $4 AND id <> $5 AND status IN ($6, $7)
+	`, entity.OfferExpired, offer.UpdatedAt, entity.OfferRejected, offer.AdId, offer.Id, entity.OfferPending, entity.OfferCountered)
+	if err != nil {
+		return entity.Offer{}, err
+	}
+
+	if offer.HideAd {
+		if _, err := tx.Exec(`UPDATE ads SET hidden = true WHERE id = $1`, offer.AdId); err != nil {
+			return entity.Offer{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.Offer{}, err
+	}
+	return toEntity(tmp), nil
+}
+
+func (r *OfferRepo) GetAdAuthorId(adId string) (string, error) {
+	var authorId string
+	err := r.db.Get(&authorId, `SELECT author_id FROM ads WHERE id = $1`, adId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", apperr.ErrAdsNotFound
+		}
+		return "", err
+	}
+	return authorId, nil
+}
+
+func toEntity(v OfferDTO) entity.Offer {
+	return entity.Offer{
+		Id:             v.Id,
+		AdId:           v.AdId,
+		BuyerId:        v.BuyerId,
+		Amount:         v.Amount,
+		Message:        v.Message,
+		CounterAmount:  v.CounterAmount.Float64,
+		CounterMessage: v.CounterMessage,
+		HideAd:         v.HideAd,
+		Status:         entity.OfferStatus(v.Status),
+		CreatedAt:      v.CreatedAt,
+		UpdatedAt:      v.UpdatedAt,
+		ExpiresAt:      v.ExpiresAt,
+	}
+}
+
+func nullAmount(v float64) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: v, Valid: v > 0}
+}
@@ -0,0 +1,278 @@
+package order_repo
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"time"
+)
+
+type OrderDTO struct {
+	Id        string         `db:"id"`
+	AdId      sql.NullString `db:"ad_id"`
+	BuyerId   string         `db:"buyer_id"`
+	SellerId  string         `db:"seller_id"`
+	Amount    float64        `db:"amount"`
+	Status    string         `db:"status"`
+	PaymentId string         `db:"payment_id"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+type OrderEventDTO struct {
+	Id         int64          `db:"id"`
+	OrderId    string         `db:"order_id"`
+	FromStatus string         `db:"from_status"`
+	ToStatus   string         `db:"to_status"`
+	ActorId    sql.NullString `db:"actor_id"`
+	Note       string         `db:"note"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+const orderColumns = `id, ad_id, buyer_id, seller_id, amount, status, payment_id, created_at, updated_at`
+
+type OrderRepo struct {
+	db *sqlx.DB
+}
+
+func NewOrderRepo(db *sqlx.DB) *OrderRepo {
+	return &OrderRepo{db}
+}
+
+// openStatuses — заказы, по которым сделка ещё идёт
+var openStatuses = []entity.OrderStatus{entity.OrderCreated, entity.OrderAuthorized, entity.OrderConfirmed}
+
+// Create — сохраняет заказ и первую запись журнала в одной транзакции.
+// Под блокировкой объявления проверяет, что у покупателя нет открытого заказа
+// по нему и открытых заказов меньше, чем единиц в наличии; иначе apperr.ErrOrderAdBusy.
+func (r *OrderRepo) Create(order entity.Order, actorId string) (entity.Order, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return entity.Order{}, err
+	}
+	defer tx.Rollback()
+
+	var quantity int
+	err = tx.Get(&quantity, `SELECT quantity FROM ads WHERE id = $1 FOR UPDATE`, order.AdId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Order{}, apperr.ErrAdsNotFound
+		}
+		return entity.Order{}, err
+	}
+
+	var open struct {
+		Total int  `db:"total"`
+		Mine  bool `db:"mine"`
+	}
+	err = tx.Get(&open, `
+		SELECT count(*) AS total, COALESCE(bool_or(buyer_id = $2), false) AS mine
+		FROM orders
+		WHERE ad_id = $1 AND status IN ($3, $4, $5)
+	`, order.AdId, order.BuyerId, openStatuses[0], openStatuses[1], openStatuses[2])
+	if err != nil {
+		return entity.Order{}, err
+	}
+	if open.Mine || open.Total >= quantity {
+		return entity.Order{}, apperr.ErrOrderAdBusy
+	}
+
+	query := `
+		INSERT INTO orders (id, ad_id, buyer_id, seller_id, amount, status, payment_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + orderColumns
+
+	var tmp OrderDTO
+	err = tx.Get(&tmp, query,
+		order.Id,
+		order.AdId,
+		order.BuyerId,
+		order.SellerId,
+		order.Amount,
+		order.Status,
+		order.PaymentId,
+		order.CreatedAt,
+		order.UpdatedAt,
+	)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	if err := insertEvent(tx, order.Id, "", order.Status, actorId, "", order.CreatedAt); err != nil {
+		return entity.Order{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.Order{}, err
+	}
+	return toEntity(tmp), nil
+}
+
+// Transition — переводит заказ из from в order.Status и пишет запись в журнал.
+// Если заказ уже не в статусе from, возвращает apperr.ErrOrderTransition.
+//
+// apply (может быть nil) вызывается внутри транзакции, когда строка заказа уже
+// заблокирована и переход записан: платёж проводится только для заказа, который
+// действительно переходит, а ошибка apply откатывает переход.
+func (r *OrderRepo) Transition(order entity.Order, from entity.OrderStatus, actorId, note string, apply func() error) (entity.Order, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return entity.Order{}, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE orders SET status = $1, payment_id = $2, updated_at = $3
+		WHERE id = $4 AND status = $5
+		RETURNING ` + orderColumns
+
+	var tmp OrderDTO
+	err = tx.Get(&tmp, query, order.Status, order.PaymentId, order.UpdatedAt, order.Id, from)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Order{}, apperr.ErrOrderTransition
+		}
+		return entity.Order{}, err
+	}
+
+	if err := insertEvent(tx, order.Id, from, order.Status, actorId, note, order.UpdatedAt); err != nil {
+		return entity.Order{}, err
+	}
+
+	if apply != nil {
+		if err := apply(); err != nil {
+			return entity.Order{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.Order{}, err
+	}
+	return toEntity(tmp), nil
+}
+
+func (r *OrderRepo) GetById(id string) (entity.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
+
+	var tmp OrderDTO
+	err := r.db.Get(&tmp, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Order{}, apperr.ErrOrderNotFound
+		}
+		return entity.Order{}, err
+	}
+	return toEntity(tmp), nil
+}
+
+// GetByUser — заказы, где пользователь покупатель или продавец
+func (r *OrderRepo) GetByUser(userId string) ([]entity.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE buyer_id = $1 OR seller_id = $1 ORDER BY created_at DESC`
+
+	var tmp []OrderDTO
+	if err := r.db.Select(&tmp, query, userId); err != nil {
+		return nil, err
+	}
+
+	orders := make([]entity.Order, 0, len(tmp))
+	for _, v := range tmp {
+		orders = append(orders, toEntity(v))
+	}
+	return orders, nil
+}
+
+func (r *OrderRepo) GetEvents(orderId string) ([]entity.OrderEvent, error) {
+	query := `
+		SELECT id, order_id, from_status, to_status, actor_id, note, created_at
+		FROM order_events
+		WHERE order_id = $1
+		ORDER BY id
+	`
+
+	var tmp []OrderEventDTO
+	if err := r.db.Select(&tmp, query, orderId); err != nil {
+		return nil, err
+	}
+
+	events := make([]entity.OrderEvent, 0, len(tmp))
+	for _, v := range tmp {
+		events = append(events, entity.OrderEvent{
+			Id:         v.Id,
+			OrderId:    v.OrderId,
+			FromStatus: entity.OrderStatus(v.FromStatus),
+			ToStatus:   entity.OrderStatus(v.ToStatus),
+			ActorId:    v.ActorId.String,
+			Note:       v.Note,
+			CreatedAt:  v.CreatedAt,
+		})
+	}
+	return events, nil
+}
+
+// GetAdForOrder — автор объявления и цена сделки для покупателя:
+// цена из принятого предложения, если оно есть, иначе цена объявления.
+// Неопубликованное объявление чужому покупателю не видно (apperr.ErrAdsNotFound),
+// по распроданному возвращает apperr.ErrAdSoldOut.
+func (r *OrderRepo) GetAdForOrder(adId, buyerId string) (string, float64, error) {
+	query := `
+		SELECT a.author_id,
+		       COALESCE(
+		           (SELECT COALESCE(o.counter_amount, o.amount)
+		            FROM offers o
+		            WHERE o.ad_id = a.id AND o.buyer_id = $2 AND o.status = $3
+		            LIMIT 1),
+		           a.price
+		       ) AS price,
+		       a.quantity,
+		       a.published
+		FROM ads a
+		WHERE a.id = $1
+	`
+
+	var res struct {
+		AuthorId  string  `db:"author_id"`
+		Price     float64 `db:"price"`
+		Quantity  int     `db:"quantity"`
+		Published bool    `db:"published"`
+	}
+	err := r.db.Get(&res, query, adId, buyerId, entity.OfferAccepted)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", 0, apperr.ErrAdsNotFound
+		}
+		return "", 0, err
+	}
+	if !res.Published && res.AuthorId != buyerId {
+		return "", 0, apperr.ErrAdsNotFound
+	}
+	if res.Quantity == 0 {
+		return "", 0, apperr.ErrAdSoldOut
+	}
+	return res.AuthorId, res.Price, nil
+}
+
+func insertEvent(tx *sqlx.Tx, orderId string, from, to entity.OrderStatus, actorId, note string, at time.Time) error {
+	query := `
+		INSERT INTO order_events (order_id, from_status, to_status, actor_id, note, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	actor := sql.NullString{String: actorId, Valid: actorId != ""}
+	_, err := tx.Exec(query, orderId, from, to, actor, note, at)
+	return err
+}
+
+func toEntity(v OrderDTO) entity.Order {
+	return entity.Order{
+		Id:        v.Id,
+		AdId:      v.AdId.String,
+		BuyerId:   v.BuyerId,
+		SellerId:  v.SellerId,
+		Amount:    v.Amount,
+		Status:    entity.OrderStatus(v.Status),
+		PaymentId: v.PaymentId,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
@@ -1,6 +1,7 @@
 package reg_repo
 
 import (
+	"database/sql"
 	"github.com/jmoiron/sqlx"
 	"market/app/internal/entity"
 	"time"
@@ -16,19 +17,21 @@ func NewRegistry(db *sqlx.DB) *Reg {
 
 func (r *Reg) Registration(user entity.User) (entity.User, error) {
 	query := `
-		INSERT INTO users (id, username, email, password_hash, created_at)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, username, email, created_at;
+		INSERT INTO users (id, username, email, password_hash, phone, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, username, email, phone, created_at;
 	`
 
 	res := struct {
-		Id        string    `db:"id"`
-		Username  string    `db:"username"`
-		Email     string    `db:"email"`
-		CreatedAt time.Time `db:"created_at"`
+		Id        string         `db:"id"`
+		Username  string         `db:"username"`
+		Email     string         `db:"email"`
+		Phone     sql.NullString `db:"phone"`
+		CreatedAt time.Time      `db:"created_at"`
 	}{}
 
-	err := r.db.Get(&res, query, user.Id, user.Username, user.Email, user.PasswordHash, user.CreatedAt)
+	err := r.db.Get(&res, query, user.Id, user.Username, user.Email, user.PasswordHash,
+		sql.NullString{String: user.Phone, Valid: user.Phone != ""}, user.CreatedAt)
 	if err != nil {
 		return entity.User{}, err
 	}
@@ -37,6 +40,7 @@ func (r *Reg) Registration(user entity.User) (entity.User, error) {
 		Id:        res.Id,
 		Username:  res.Username,
 		Email:     res.Email,
+		Phone:     res.Phone.String,
 		CreatedAt: res.CreatedAt,
 	}
 
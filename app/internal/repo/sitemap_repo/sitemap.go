@@ -0,0 +1,79 @@
+package sitemap_repo
+
+import (
+	"github.com/jmoiron/sqlx"
+	"market/app/internal/entity"
+	"time"
+)
+
+// publicAds — публичные объявления в стабильном порядке и время их последнего изменения:
+// публикация или последнее добавленное изображение
+const publicAds = `
+	SELECT id,
+	       created_at,
+	       GREATEST(
+	           COALESCE(publish_at, created_at),
+	           COALESCE((SELECT MAX(created_at) FROM ad_images WHERE ad_images.ad_id = ads.id), created_at)
+	       ) AS lastmod
+	FROM ads
+	WHERE published = true AND hidden = false
+`
+
+type SitemapRepo struct {
+	db *sqlx.DB
+}
+
+func NewSitemapRepo(db *sqlx.DB) *SitemapRepo {
+	return &SitemapRepo{db}
+}
+
+// Pages — страницы по pageSize объявлений и самое позднее изменение на каждой
+func (s *SitemapRepo) Pages(pageSize int) ([]entity.SitemapPage, error) {
+	query := `
+		SELECT (rn - 1) / $1 + 1 AS number, MAX(lastmod) AS lastmod
+		FROM (
+			SELECT row_number() OVER (ORDER BY created_at, id) AS rn, lastmod
+			FROM (` + publicAds + `) p
+		) t
+		GROUP BY number
+		ORDER BY number
+	`
+
+	var tmp []struct {
+		Number  int       `db:"number"`
+		LastMod time.Time `db:"lastmod"`
+	}
+	if err := s.db.Select(&tmp, query, pageSize); err != nil {
+		return nil, err
+	}
+
+	pages := make([]entity.SitemapPage, 0, len(tmp))
+	for _, v := range tmp {
+		pages = append(pages, entity.SitemapPage{Number: v.Number, LastMod: v.LastMod})
+	}
+	return pages, nil
+}
+
+// Page — объявления страницы number (с 1) в том же порядке, что и в Pages
+func (s *SitemapRepo) Page(number, pageSize int) ([]entity.SitemapURL, error) {
+	query := `
+		SELECT id, lastmod
+		FROM (` + publicAds + `) p
+		ORDER BY created_at, id
+		LIMIT $1 OFFSET $2
+	`
+
+	var tmp []struct {
+		Id      string    `db:"id"`
+		LastMod time.Time `db:"lastmod"`
+	}
+	if err := s.db.Select(&tmp, query, pageSize, (number-1)*pageSize); err != nil {
+		return nil, err
+	}
+
+	urls := make([]entity.SitemapURL, 0, len(tmp))
+	for _, v := range tmp {
+		urls = append(urls, entity.SitemapURL{AdId: v.Id, LastMod: v.LastMod})
+	}
+	return urls, nil
+}
@@ -0,0 +1,49 @@
+package tag_repo
+
+import (
+	"github.com/jmoiron/sqlx"
+	"market/app/internal/entity"
+	"strings"
+)
+
+type TagRepo struct {
+	db *sqlx.DB
+}
+
+func NewTagRepo(db *sqlx.DB) *TagRepo {
+	return &TagRepo{db}
+}
+
+// Suggest — теги, начинающиеся с prefix, по убыванию числа опубликованных объявлений с ними
+func (t *TagRepo) Suggest(prefix string, limit int) ([]entity.Tag, error) {
+	query := `
+		SELECT tags.name, COUNT(*) AS count
+		FROM tags
+		JOIN ad_tags ON ad_tags.tag_id = tags.id
+		JOIN ads ON ads.id = ad_tags.ad_id
+		WHERE tags.name LIKE $1 ESCAPE '\'
+		  AND ads.published = true
+		  AND ads.hidden = false
+		GROUP BY tags.name
+		ORDER BY count DESC, tags.name
+		LIMIT $2
+	`
+
+	var tmp []struct {
+		Name  string `db:"name"`
+		Count int    `db:"count"`
+	}
+	if err := t.db.Select(&tmp, query, escapeLike(prefix)+"%", limit); err != nil {
+		return nil, err
+	}
+
+	tags := make([]entity.Tag, 0, len(tmp))
+	for _, v := range tmp {
+		tags = append(tags, entity.Tag{Name: v.Name, Count: v.Count})
+	}
+	return tags, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -4,8 +4,17 @@ import (
 	"github.com/gorilla/mux"
 	"market/app/internal/handler/ads"
 	"market/app/internal/handler/auth"
+	"market/app/internal/handler/feeds"
+	"market/app/internal/handler/gql"
 	"market/app/internal/handler/image"
+	"market/app/internal/handler/offers"
+	"market/app/internal/handler/orders"
+	"market/app/internal/handler/pages"
 	"market/app/internal/handler/reg"
+	"market/app/internal/handler/sitemap"
+	"market/app/internal/handler/static"
+	"market/app/internal/handler/stream"
+	"market/app/internal/handler/tags"
 	"net/http"
 )
 
@@ -14,12 +23,36 @@ func NewRouter(
 	regHandler *reg.RegistryHandler,
 	adsHandler *ads.AdsHandler,
 	imageHandler *image.ImageHandler,
+	streamHandler *stream.StreamHandler,
+	offersHandler *offers.OffersHandler,
+	ordersHandler *orders.OrdersHandler,
+	tagsHandler *tags.TagsHandler,
+	feedsHandler *feeds.FeedsHandler,
+	pagesHandler *pages.PagesHandler,
+	sitemapHandler *sitemap.SitemapHandler,
+	graphqlHandler *gql.GraphQLHandler,
+	staticHandler *static.StaticHandler,
 	authMiddleware func(http.Handler) http.Handler,
 	authOptionalMiddleware func(http.Handler) http.Handler,
 ) *mux.Router {
 	r := mux.NewRouter()
 	api := r.PathPrefix("/api/v1").Subrouter()
 
+	// HTML pages
+	r.Handle("/", http.RedirectHandler("/ads", http.StatusFound)).Methods(http.MethodGet)
+	r.HandleFunc("/ads", pagesHandler.Feed).Methods(http.MethodGet)
+	r.HandleFunc("/ads/{id}", pagesHandler.Ad).Methods(http.MethodGet)
+
+	// Uploaded images
+	r.HandleFunc("/static/upload/{name}", staticHandler.ServeImage).Methods(http.MethodGet, http.MethodHead)
+
+	// GraphQL
+	r.Handle("/graphql", authOptionalMiddleware(graphqlHandler)).Methods(http.MethodPost)
+
+	// Sitemap
+	r.HandleFunc("/sitemap.xml", sitemapHandler.Index).Methods(http.MethodGet)
+	r.HandleFunc("/sitemaps/ads-{n:[0-9]+}.xml", sitemapHandler.Page).Methods(http.MethodGet)
+
 	// Auth
 	api.HandleFunc("/register", regHandler.RegistrationHandler).Methods(http.MethodPost)
 	api.HandleFunc("/login", authHandler.LoginHandler).Methods(http.MethodPost)
@@ -30,11 +63,45 @@ func NewRouter(
 	api.Handle("/ads", authOptionalMiddleware(http.HandlerFunc(adsHandler.GetAllAds))).Methods(http.MethodGet)
 	api.Handle("/ads/{id}", authOptionalMiddleware(http.HandlerFunc(adsHandler.GetAdByID))).Methods(http.MethodGet)
 	api.Handle("/ads/{id}", authMiddleware(http.HandlerFunc(adsHandler.Delete))).Methods(http.MethodDelete)
+	api.Handle("/ads/{id}/similar", authOptionalMiddleware(http.HandlerFunc(adsHandler.GetSimilar))).Methods(http.MethodGet)
+	api.Handle("/ads/{id}/sold", authMiddleware(http.HandlerFunc(adsHandler.SellUnits))).Methods(http.MethodPost)
+	api.Handle("/ads/{id}/contact/reveal", authMiddleware(http.HandlerFunc(adsHandler.RevealContact))).Methods(http.MethodPost)
+	api.Handle("/ads/{id}/contact/stats", authMiddleware(http.HandlerFunc(adsHandler.GetContactStats))).Methods(http.MethodGet)
 
 	// Images
 	api.Handle("/ads/{id}/images", authMiddleware(http.HandlerFunc(imageHandler.AddImage))).Methods(http.MethodPost)
-	api.HandleFunc("/ads/{id}/images", imageHandler.GetImages).Methods(http.MethodGet)
-	api.HandleFunc("/ads/images/{id}", imageHandler.GetImageById).Methods(http.MethodGet)
+	api.Handle("/ads/{id}/images", authOptionalMiddleware(http.HandlerFunc(imageHandler.GetImages))).Methods(http.MethodGet)
+	api.Handle("/ads/{id}/images/order", authMiddleware(http.HandlerFunc(imageHandler.ReorderImages))).Methods(http.MethodPatch)
+	api.Handle("/ads/{id}/images/privacy", authMiddleware(http.HandlerFunc(imageHandler.SetPrivacy))).Methods(http.MethodPatch)
+	api.Handle("/ads/images/{id}", authOptionalMiddleware(http.HandlerFunc(imageHandler.GetImageById))).Methods(http.MethodGet)
+	api.Handle("/ads/images/{id}", authMiddleware(http.HandlerFunc(imageHandler.DeleteImage))).Methods(http.MethodDelete)
+	api.Handle("/me/storage", authMiddleware(http.HandlerFunc(imageHandler.StorageUsage))).Methods(http.MethodGet)
+
+	// Feeds
+	api.HandleFunc("/feeds/ads.atom", feedsHandler.Atom).Methods(http.MethodGet)
+	api.HandleFunc("/feeds/ads.rss", feedsHandler.RSS).Methods(http.MethodGet)
+
+	// Tags
+	api.HandleFunc("/tags/suggest", tagsHandler.Suggest).Methods(http.MethodGet)
+
+	// Offers
+	api.Handle("/ads/{id}/offers", authMiddleware(http.HandlerFunc(offersHandler.Create))).Methods(http.MethodPost)
+	api.Handle("/ads/{id}/offers", authMiddleware(http.HandlerFunc(offersHandler.GetByAd))).Methods(http.MethodGet)
+	api.Handle("/offers/{id}/accept", authMiddleware(http.HandlerFunc(offersHandler.Accept))).Methods(http.MethodPost)
+	api.Handle("/offers/{id}/reject", authMiddleware(http.HandlerFunc(offersHandler.Reject))).Methods(http.MethodPost)
+	api.Handle("/offers/{id}/counter", authMiddleware(http.HandlerFunc(offersHandler.Counter))).Methods(http.MethodPost)
+
+	// Orders
+	api.Handle("/ads/{id}/orders", authMiddleware(http.HandlerFunc(ordersHandler.Create))).Methods(http.MethodPost)
+	api.Handle("/orders", authMiddleware(http.HandlerFunc(ordersHandler.GetMy))).Methods(http.MethodGet)
+	api.Handle("/orders/{id}", authMiddleware(http.HandlerFunc(ordersHandler.GetById))).Methods(http.MethodGet)
+	api.Handle("/orders/{id}/confirm", authMiddleware(http.HandlerFunc(ordersHandler.Confirm))).Methods(http.MethodPost)
+	api.Handle("/orders/{id}/receive", authMiddleware(http.HandlerFunc(ordersHandler.Receive))).Methods(http.MethodPost)
+	api.Handle("/orders/{id}/cancel", authMiddleware(http.HandlerFunc(ordersHandler.Cancel))).Methods(http.MethodPost)
+
+	// Events
+	api.Handle("/events", authOptionalMiddleware(http.HandlerFunc(streamHandler.Stream))).Methods(http.MethodGet)
+	api.Handle("/events/ws", authOptionalMiddleware(http.HandlerFunc(streamHandler.StreamWS))).Methods(http.MethodGet)
 
 	return r
 }
@@ -0,0 +1,96 @@
+package local
+
+import (
+	"errors"
+	"io/fs"
+	"market/app/internal/apperr"
+	"market/app/internal/storage"
+	"os"
+)
+
+// Storage — файлы в каталоге на диске. Каталог открыт как os.Root:
+// ключ не может указать на файл за его пределами.
+type Storage struct {
+	root *os.Root
+}
+
+func New(dir string) (*Storage, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Storage{root: root}, nil
+}
+
+func (s *Storage) Put(key string, data []byte, contentType string) error {
+	file, err := s.root.OpenFile(key, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (s *Storage) Get(key string) (storage.Object, error) {
+	file, err := s.root.Open(key)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return storage.Object{}, apperr.ErrBlobNotFound
+		}
+		return storage.Object{}, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return storage.Object{}, err
+	}
+	if !info.Mode().IsRegular() {
+		file.Close()
+		return storage.Object{}, apperr.ErrBlobNotFound
+	}
+	return storage.Object{Body: file, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// Delete — удалить отсутствующий файл не ошибка
+func (s *Storage) Delete(key string) error {
+	if err := s.root.Remove(key); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List — обычные файлы каталога; вложенных каталогов хранилище не создаёт
+func (s *Storage) List() ([]storage.Info, error) {
+	entries, err := fs.ReadDir(s.root.FS(), ".")
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]storage.Info, 0, len(entries))
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			// файл удалили между чтением каталога и Stat
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		res = append(res, storage.Info{Key: e.Name(), Size: info.Size(), ModTime: info.ModTime()})
+	}
+	return res, nil
+}
+
+func (s *Storage) URL(key string) string {
+	return storage.PublicPrefix + key
+}
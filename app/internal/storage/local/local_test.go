@@ -0,0 +1,73 @@
+package local
+
+import (
+	"errors"
+	"io"
+	"market/app/internal/apperr"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStorageRoundTrip(t *testing.T) {
+	s, err := New(filepath.Join(t.TempDir(), "upload"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := s.Put("a.png", []byte("image"), "image/png"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	obj, err := s.Get("a.png")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, err := io.ReadAll(obj.Body)
+	obj.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "image" || obj.Size != 5 {
+		t.Errorf("Get() = %q (%d bytes), want %q", data, obj.Size, "image")
+	}
+
+	list, err := s.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 1 || list[0].Key != "a.png" || list[0].Size != 5 {
+		t.Errorf("List() = %+v, want a.png of 5 bytes", list)
+	}
+
+	if err := s.Delete("a.png"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.Get("a.png"); !errors.Is(err, apperr.ErrBlobNotFound) {
+		t.Errorf("Get() after Delete error = %v, want %v", err, apperr.ErrBlobNotFound)
+	}
+	if err := s.Delete("a.png"); err != nil {
+		t.Errorf("second Delete() error = %v", err)
+	}
+}
+
+func TestStorageStaysInsideRoot(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := New(filepath.Join(dir, "upload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Get("../secret.txt"); err == nil {
+		t.Error("Get() outside the root succeeded")
+	}
+	if err := s.Put("../evil.png", []byte("x"), "image/png"); err == nil {
+		t.Error("Put() outside the root succeeded")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "evil.png")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("file written outside the root: %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"io"
+	"time"
+)
+
+// Object — содержимое файла из хранилища. Body поддерживает Seek,
+// поэтому его можно отдавать через http.ServeContent с Range-запросами.
+type Object struct {
+	Body    io.ReadSeekCloser
+	Size    int64
+	ModTime time.Time
+}
+
+// Info — файл в списке содержимого хранилища
+type Info struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// PublicPrefix — путь, по которому приложение само отдаёт файлы из хранилища
+const PublicPrefix = "/static/upload/"
@@ -0,0 +1,127 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"market/app/internal/apperr"
+	"market/app/internal/storage"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// requestTimeout — на загрузку и удаление; чтение ограничивает само HTTP-соединение клиента
+const requestTimeout = 30 * time.Second
+
+type Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+	// PublicURL — адрес бакета для браузеров (CDN или публичный бакет);
+	// пустой — файлы отдаёт приложение по /static/upload/
+	PublicURL string
+}
+
+// Storage — S3-совместимое хранилище (AWS S3, MinIO и т.п.)
+type Storage struct {
+	client    *minio.Client
+	bucket    string
+	publicURL string
+}
+
+// New — подключается к хранилищу и создаёт бакет, если его ещё нет
+func New(cfg Config) (*Storage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 client creation failed: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("s3 bucket check failed: %w", err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{Region: cfg.Region}); err != nil {
+			return nil, fmt.Errorf("s3 bucket creation failed: %w", err)
+		}
+	}
+
+	return &Storage{
+		client:    client,
+		bucket:    cfg.Bucket,
+		publicURL: strings.TrimRight(cfg.PublicURL, "/"),
+	}, nil
+}
+
+func (s *Storage) Put(key string, data []byte, contentType string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType:  contentType,
+		CacheControl: "public, max-age=31536000, immutable",
+	})
+	return err
+}
+
+// Get — minio.Object читает объект по частям и поддерживает Seek через Range-запросы
+func (s *Storage) Get(key string) (storage.Object, error) {
+	obj, err := s.client.GetObject(context.Background(), s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return storage.Object{}, toErr(err)
+	}
+
+	info, err := obj.Stat()
+	if err != nil {
+		obj.Close()
+		return storage.Object{}, toErr(err)
+	}
+	return storage.Object{Body: obj, Size: info.Size, ModTime: info.LastModified}, nil
+}
+
+// Delete — S3 не возвращает ошибку для отсутствующего ключа
+func (s *Storage) Delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// List — все объекты бакета; ListObjects сам проходит по страницам
+func (s *Storage) List() ([]storage.Info, error) {
+	var res []storage.Info
+	for obj := range s.client.ListObjects(context.Background(), s.bucket, minio.ListObjectsOptions{Recursive: true}) {
+		if obj.Err != nil {
+			return nil, obj.Err
+		}
+		res = append(res, storage.Info{Key: obj.Key, Size: obj.Size, ModTime: obj.LastModified})
+	}
+	return res, nil
+}
+
+func (s *Storage) URL(key string) string {
+	if s.publicURL != "" {
+		return s.publicURL + "/" + key
+	}
+	return storage.PublicPrefix + key
+}
+
+func toErr(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return apperr.ErrBlobNotFound
+	}
+	return err
+}
@@ -0,0 +1,281 @@
+package s3
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"market/app/internal/apperr"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 — S3 в памяти: бакеты и объекты по path-style адресам, ровно столько API,
+// сколько использует Storage
+type fakeS3 struct {
+	mu      sync.Mutex
+	buckets map[string]map[string]fakeObject
+}
+
+type fakeObject struct {
+	data        []byte
+	contentType string
+	modTime     time.Time
+}
+
+func newFakeS3(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(&fakeS3{buckets: make(map[string]map[string]fakeObject)})
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	objects, exists := f.buckets[bucket]
+
+	if key == "" {
+		switch {
+		case r.Method == http.MethodHead && exists:
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPut:
+			f.buckets[bucket] = make(map[string]fakeObject)
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodGet && exists && r.URL.Query().Get("list-type") == "2":
+			writeList(w, bucket, objects)
+		default:
+			writeError(w, http.StatusNotFound, "NoSuchBucket", bucket, "")
+		}
+		return
+	}
+	if !exists {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", bucket, key)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := readPayload(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", bucket, key)
+			return
+		}
+		objects[key] = fakeObject{data: data, contentType: r.Header.Get("Content-Type"), modTime: time.Now().UTC().Truncate(time.Second)}
+		w.Header().Set("ETag", etag(data))
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		obj, ok := objects[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey", bucket, key)
+			return
+		}
+		w.Header().Set("ETag", etag(obj.data))
+		w.Header().Set("Content-Type", obj.contentType)
+		http.ServeContent(w, r, key, obj.modTime, bytes.NewReader(obj.data))
+	case http.MethodDelete:
+		delete(objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", bucket, key)
+	}
+}
+
+// readPayload — тело PUT; по HTTP без TLS клиент шлёт его в формате aws-chunked:
+// "<размер в hex>;chunk-signature=...\r\n<данные>\r\n", последний кусок нулевой
+func readPayload(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+
+	var res []byte
+	br := bufio.NewReader(r.Body)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return res, nil
+		}
+		chunk := make([]byte, size+2)
+		if _, err := io.ReadFull(br, chunk); err != nil {
+			return nil, err
+		}
+		res = append(res, chunk[:size]...)
+	}
+}
+
+func writeList(w http.ResponseWriter, bucket string, objects map[string]fakeObject) {
+	type content struct {
+		Key          string
+		LastModified string
+		ETag         string
+		Size         int64
+		StorageClass string
+	}
+	res := struct {
+		XMLName     xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+		Name        string
+		Prefix      string
+		KeyCount    int
+		MaxKeys     int
+		IsTruncated bool
+		Contents    []content
+	}{Name: bucket, KeyCount: len(objects), MaxKeys: 1000}
+
+	keys := make([]string, 0, len(objects))
+	for key := range objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		obj := objects[key]
+		res.Contents = append(res.Contents, content{
+			Key:          key,
+			LastModified: obj.modTime.Format("2006-01-02T15:04:05.000Z"),
+			ETag:         etag(obj.data),
+			Size:         int64(len(obj.data)),
+			StorageClass: "STANDARD",
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	xml.NewEncoder(w).Encode(res)
+}
+
+func writeError(w http.ResponseWriter, status int, code, bucket, key string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	xml.NewEncoder(w).Encode(struct {
+		XMLName    xml.Name `xml:"Error"`
+		Code       string
+		Message    string
+		BucketName string
+		Key        string
+		RequestId  string
+	}{Code: code, Message: code, BucketName: bucket, Key: key, RequestId: "fake"})
+}
+
+func etag(data []byte) string {
+	return fmt.Sprintf("%q", strconv.Itoa(len(data)))
+}
+
+func newTestStorage(t *testing.T, publicURL string) *Storage {
+	t.Helper()
+	srv := newFakeS3(t)
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := New(Config{
+		Endpoint:  u.Host,
+		Region:    "us-east-1",
+		Bucket:    "images",
+		AccessKey: "test",
+		SecretKey: "test-secret",
+		PublicURL: publicURL,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return s
+}
+
+func TestStorageRoundTrip(t *testing.T) {
+	s := newTestStorage(t, "")
+	data := []byte("\x89PNG\r\n\x1a\nfake image body")
+
+	if err := s.Put("a.png", data, "image/png"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	obj, err := s.Get("a.png")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if obj.Size != int64(len(data)) {
+		t.Errorf("Size = %d, want %d", obj.Size, len(data))
+	}
+	got, err := io.ReadAll(obj.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("body = %q, want %q", got, data)
+	}
+
+	// Seek нужен http.ServeContent для Range-запросов
+	if _, err := obj.Body.Seek(8, io.SeekStart); err != nil {
+		t.Fatalf("Seek() error = %v", err)
+	}
+	tail, err := io.ReadAll(obj.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(tail, data[8:]) {
+		t.Errorf("body after seek = %q, want %q", tail, data[8:])
+	}
+	obj.Body.Close()
+
+	if err := s.Put("b_thumb.png", []byte("thumb"), "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	list, err := s.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	keys := make([]string, 0, len(list))
+	for _, info := range list {
+		keys = append(keys, info.Key)
+	}
+	if want := []string{"a.png", "b_thumb.png"}; !slices.Equal(keys, want) {
+		t.Errorf("List() keys = %v, want %v", keys, want)
+	}
+
+	if err := s.Delete("a.png"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.Get("a.png"); !errors.Is(err, apperr.ErrBlobNotFound) {
+		t.Errorf("Get() after Delete error = %v, want %v", err, apperr.ErrBlobNotFound)
+	}
+	// удаление отсутствующего ключа — не ошибка
+	if err := s.Delete("a.png"); err != nil {
+		t.Errorf("second Delete() error = %v", err)
+	}
+}
+
+func TestStorageURL(t *testing.T) {
+	tests := []struct {
+		publicURL string
+		want      string
+	}{
+		{"", "/static/upload/a.png"},
+		{"https://cdn.example.com/images/", "https://cdn.example.com/images/a.png"},
+	}
+	for _, tt := range tests {
+		if got := newTestStorage(t, tt.publicURL).URL("a.png"); got != tt.want {
+			t.Errorf("URL() with public %q = %q, want %q", tt.publicURL, got, tt.want)
+		}
+	}
+}
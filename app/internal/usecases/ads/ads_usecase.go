@@ -3,25 +3,62 @@ package ads
 import (
 	"fmt"
 	"market/app/internal/apperr"
+	"market/app/internal/cache"
 	"market/app/internal/entity"
+	"market/app/internal/events"
 	"market/app/internal/usecases/ads/dto"
 	"market/app/internal/utils"
 	"time"
+	"unicode/utf8"
 )
 
-type ImgRepo interface {
-	GetImages(adId string) ([]entity.AdImage, error)
+// Images — изображения объявления такими, какими их видит viewerId
+// (закрытые — только авторизованным и по подписанным ссылкам)
+type Images interface {
+	ViewImages(adId, viewerId string) ([]entity.AdImage, error)
 }
+
+const (
+	maxTitleLength       = 50
+	maxDescriptionLength = 1000
+)
+
+const (
+	feedDefaultLimit = 10
+	feedMaxLimit     = 100
+	feedMaxSortKeys  = 3
+)
+
+var feedSortFields = map[string]bool{"created_at": true, "price": true, "title": true}
+
+const (
+	similarTTL          = 5 * time.Minute
+	similarDefaultLimit = 10
+	similarMaxLimit     = 50
+)
+
 type Ads struct {
-	repo AdsRepo
-	img  ImgRepo
+	repo    AdsRepo
+	img     Images
+	events  Publisher
+	reveals RateLimiter
+	similar *cache.TTL[string, []dto.AdResponse]
 }
 
-func NewAds(repo AdsRepo, img ImgRepo) *Ads {
-	return &Ads{repo, img}
+func NewAds(repo AdsRepo, img Images, events Publisher, reveals RateLimiter) *Ads {
+	return &Ads{
+		repo:    repo,
+		img:     img,
+		events:  events,
+		reveals: reveals,
+		similar: cache.NewTTL[string, []dto.AdResponse](similarTTL),
+	}
 }
 
 func (a *Ads) Create(ad entity.Ad) (entity.Ad, error) {
+	if err := a.validateAd(ad); err != nil {
+		return entity.Ad{}, err
+	}
 
 	id, err := utils.GenerateUUID()
 	if err != nil {
@@ -31,12 +68,38 @@ func (a *Ads) Create(ad entity.Ad) (entity.Ad, error) {
 	ad.Id = id
 	ad.CreatedAt = time.Now().UTC()
 
+	if !ad.PublishAt.IsZero() && !ad.PublishAt.After(ad.CreatedAt) {
+		return entity.Ad{}, apperr.ErrInvalidPublishAt
+	}
+	ad.PublishAt = ad.PublishAt.UTC()
+	ad.Published = ad.PublishAt.IsZero()
+
+	ad.Tags = entity.NormalizeTags(ad.Tags)
+	if err := a.validateTags(ad.Tags); err != nil {
+		return entity.Ad{}, err
+	}
+
+	if ad.Quantity < 1 || ad.Quantity > entity.MaxAdQuantity {
+		return entity.Ad{}, apperr.ErrInvalidQuantity
+	}
+
+	phone, ok := entity.NormalizePhone(ad.ContactPhone)
+	if !ok {
+		return entity.Ad{}, apperr.ErrInvalidPhone
+	}
+	ad.ContactPhone = phone
+
 	savedAd, err := a.repo.Create(ad)
 
 	if err != nil {
 		return entity.Ad{}, fmt.Errorf("ads creation failed: %w", err)
 	}
 
+	// отложенное объявление попадёт в поток событий, когда его опубликует планировщик
+	if savedAd.Published {
+		a.publishCreated(savedAd)
+	}
+
 	return savedAd, nil
 }
 
@@ -45,22 +108,36 @@ func (a *Ads) GetById(adId, userId string) (dto.AdDetailed, error) {
 	if err != nil {
 		return dto.AdDetailed{}, fmt.Errorf("get by id failed: %w", err)
 	}
+	if !ad.Published && ad.AuthorId != userId {
+		return dto.AdDetailed{}, apperr.ErrAdsNotFound
+	}
 
 	authorName, err := a.repo.GetAuthorName(ad.AuthorId)
 	if err != nil {
 		return dto.AdDetailed{}, fmt.Errorf("get author failed: %w", err)
 	}
 
-	images, err := a.img.GetImages(ad.Id)
+	ad.Tags, err = a.repo.GetTags(ad.Id)
+	if err != nil {
+		return dto.AdDetailed{}, fmt.Errorf("get tags failed: %w", err)
+	}
+
+	images, err := a.img.ViewImages(ad.Id, userId)
 	if err != nil {
 		return dto.AdDetailed{}, fmt.Errorf("get images failed: %w", err)
 	}
 
+	contact, err := a.repo.GetContact(ad.Id)
+	if err != nil {
+		return dto.AdDetailed{}, fmt.Errorf("get contact failed: %w", err)
+	}
+
 	return dto.AdDetailed{
 		Ad:      ad,
 		Author:  authorName,
 		Images:  images,
 		IsOwner: ad.AuthorId == userId,
+		Phone:   entity.MaskPhone(contact.Phone),
 	}, nil
 }
 
@@ -78,61 +155,266 @@ func (a *Ads) Delete(adId, userId string) error {
 		return fmt.Errorf("delete ad failed: %w", err)
 	}
 
+	a.similar.Delete(ad.Id)
+	// о неопубликованном объявлении подписчики не знали
+	if ad.Published {
+		a.events.Publish(events.Event{
+			Type:   events.AdDeleted,
+			AdId:   ad.Id,
+			UserId: ad.AuthorId,
+		})
+	}
+
 	return nil
 }
-func (a *Ads) GetAll(userId string, limit, offset int, sortBy, order string, priceMin, priceMax float64) ([]dto.AdResponse, error) {
-
-	ads, err := a.repo.GetAll(limit, offset, sortBy, order, priceMin, priceMax)
+func (a *Ads) GetAll(userId string, filter entity.AdsFilter) ([]dto.AdResponse, error) {
+	ads, err := a.Feed(userId, filter)
 	if err != nil {
-
-		return nil, fmt.Errorf("get all failed: %w", err)
+		return nil, err
 	}
 
 	var result []dto.AdResponse
 	for _, ad := range ads {
-		authorName, err := a.repo.GetAuthorName(ad.AuthorId)
+		item, err := a.toResponse(ad, userId)
 		if err != nil {
-
-			return nil, fmt.Errorf("author fetch failed: %w", err)
+			return nil, err
 		}
+		result = append(result, item)
+	}
 
-		images, err := a.img.GetImages(ad.Id)
-		if err != nil {
+	return result, nil
+}
 
-			return nil, fmt.Errorf("get images failed: %w", err)
-		}
+// Feed — лента с теми же фильтрами и проверками, что и GetAll, но без авторов,
+// изображений и тегов: их можно догрузить пачкой через Authors, TagsByAds и img.ImagesByAds
+func (a *Ads) Feed(userId string, filter entity.AdsFilter) ([]entity.Ad, error) {
+	if filter.Limit == 0 {
+		filter.Limit = feedDefaultLimit
+	}
+	if len(filter.Sort) == 0 {
+		filter.Sort = []entity.SortKey{{Field: "created_at", Desc: true}}
+	}
+	filter.ViewerId = userId
+	filter.Tags = entity.NormalizeTags(filter.Tags)
 
-		imageURLs := make([]string, 0, len(images))
-		for _, img := range images {
-			imageURLs = append(imageURLs, img.ImageURL)
-		}
+	if err := a.validateGetAll(filter); err != nil {
+		return nil, err
+	}
+	filter = withPricePreset(filter)
+	if filter.PriceMax > 0 && filter.PriceMax < filter.PriceMin {
+		return nil, apperr.NewFieldError("price", "does not overlap with min and max")
+	}
+
+	ads, err := a.repo.GetAll(filter)
+	if err != nil {
+		return nil, fmt.Errorf("get all failed: %w", err)
+	}
+	return ads, nil
+}
+
+// Authors — авторы по списку id, ключ — id пользователя
+func (a *Ads) Authors(ids []string) (map[string]entity.User, error) {
+	users, err := a.repo.GetAuthors(ids)
+	if err != nil {
+		return nil, fmt.Errorf("get authors failed: %w", err)
+	}
+
+	res := make(map[string]entity.User, len(users))
+	for _, u := range users {
+		res[u.Id] = u
+	}
+	return res, nil
+}
+
+// TagsByAds — теги нескольких объявлений, ключ — id объявления
+func (a *Ads) TagsByAds(adIds []string) (map[string][]string, error) {
+	tags, err := a.repo.GetTagsByAds(adIds)
+	if err != nil {
+		return nil, fmt.Errorf("get tags failed: %w", err)
+	}
+	return tags, nil
+}
 
-		dto := dto.AdResponse{
-			Id:          ad.Id,
-			Title:       ad.Title,
-			Description: ad.Description,
-			Price:       ad.Price,
-			Author:      authorName,
-			AuthorID:    ad.AuthorId,
-			IsOwner:     ad.AuthorId == userId,
-			Images:      imageURLs,
+// Similar — похожие объявления других авторов. Ранжирование дорогое,
+// поэтому результат кэшируется на similarTTL для каждого объявления;
+// видимость самого объявления проверяется при каждом запросе.
+func (a *Ads) Similar(adId, userId string, limit int) ([]dto.AdResponse, error) {
+	if limit < 0 || limit > similarMaxLimit {
+		return nil, apperr.ErrInvalidLimit
+	}
+	if limit == 0 {
+		limit = similarDefaultLimit
+	}
+
+	ad, err := a.repo.GetById(adId)
+	if err != nil {
+		return nil, fmt.Errorf("get by id failed: %w", err)
+	}
+	if !ad.Published && ad.AuthorId != userId {
+		return nil, apperr.ErrAdsNotFound
+	}
+
+	cached, ok := a.similar.Get(adId)
+	if !ok {
+
+		ads, err := a.repo.GetSimilar(ad, similarMaxLimit)
+		if err != nil {
+			return nil, fmt.Errorf("get similar failed: %w", err)
 		}
 
-		result = append(result, dto)
+		cached = make([]dto.AdResponse, 0, len(ads))
+		// кэш общий, поэтому собирается как для анонимного пользователя:
+		// закрытые изображения в похожих не показываются
+		for _, v := range ads {
+			item, err := a.toResponse(v, "")
+			if err != nil {
+				return nil, err
+			}
+			cached = append(cached, item)
+		}
+		a.similar.Set(adId, cached)
 	}
 
+	// кэш общий для всех пользователей, признак владельца проставляем на копии
+	result := make([]dto.AdResponse, 0, min(limit, len(cached)))
+	for _, item := range cached[:min(limit, len(cached))] {
+		item.IsOwner = item.AuthorID == userId
+		result = append(result, item)
+	}
 	return result, nil
 }
 
-func (a *Ads) validateGetAll(limit, offset int, priceMin, priceMax float64) error {
-	if limit <= 0 {
-		return apperr.ErrInvalidLimit
+func (a *Ads) toResponse(ad entity.Ad, userId string) (dto.AdResponse, error) {
+	authorName, err := a.repo.GetAuthorName(ad.AuthorId)
+	if err != nil {
+		return dto.AdResponse{}, fmt.Errorf("author fetch failed: %w", err)
 	}
-	if offset < 0 {
-		return apperr.ErrInvalidOffset
+
+	images, err := a.img.ViewImages(ad.Id, userId)
+	if err != nil {
+		return dto.AdResponse{}, fmt.Errorf("get images failed: %w", err)
+	}
+
+	// в ленте карточки маленькие, поэтому отдаём уменьшенные копии
+	imageURLs := make([]string, 0, len(images))
+	for _, img := range images {
+		imageURLs = append(imageURLs, img.Variant(entity.ImageThumb))
+	}
+
+	tags, err := a.repo.GetTags(ad.Id)
+	if err != nil {
+		return dto.AdResponse{}, fmt.Errorf("get tags failed: %w", err)
+	}
+
+	return dto.AdResponse{
+		Id:          ad.Id,
+		Title:       ad.Title,
+		Description: ad.Description,
+		Price:       ad.Price,
+		CreatedAt:   ad.CreatedAt,
+		Author:      authorName,
+		AuthorID:    ad.AuthorId,
+		IsOwner:     ad.AuthorId == userId,
+		Images:      imageURLs,
+		Published:   ad.Published,
+		PublishAt:   ad.PublishAt,
+		Tags:        tags,
+		Quantity:    ad.Quantity,
+	}, nil
+}
+
+// validateAd — те же ограничения, что проверяет REST-хендлер, для остальных транспортов
+func (a *Ads) validateAd(ad entity.Ad) error {
+	if utf8.RuneCountInString(ad.Title) > maxTitleLength {
+		return apperr.ErrTitleTooLong
 	}
-	if priceMin < 0 || priceMax < 0 || priceMax < priceMin {
+	if utf8.RuneCountInString(ad.Description) > maxDescriptionLength {
+		return apperr.ErrDescriptionTooLong
+	}
+	if ad.Price <= 0 {
 		return apperr.ErrInvalidPrice
 	}
 	return nil
 }
+
+func (a *Ads) validateTags(tags []string) error {
+	if len(tags) > entity.MaxTagsPerAd {
+		return apperr.ErrTooManyTags
+	}
+	for _, tag := range tags {
+		if entity.TagTooLong(tag) {
+			return apperr.ErrTagTooLong
+		}
+	}
+	return nil
+}
+
+func (a *Ads) publishCreated(ad entity.Ad) {
+	a.events.Publish(events.Event{
+		Type:   events.AdCreated,
+		AdId:   ad.Id,
+		UserId: ad.AuthorId,
+		Ad:     &ad,
+	})
+}
+
+// withPricePreset — сужает PriceMin/PriceMax до диапазона PricePreset; явные min и max,
+// заданные внутри диапазона, остаются
+func withPricePreset(filter entity.AdsFilter) entity.AdsFilter {
+	preset, ok := entity.PricePresets[filter.PricePreset]
+	if !ok {
+		return filter
+	}
+	if preset.Min > filter.PriceMin {
+		filter.PriceMin = preset.Min
+	}
+	if preset.Max > 0 && (filter.PriceMax == 0 || preset.Max < filter.PriceMax) {
+		filter.PriceMax = preset.Max
+	}
+	return filter
+}
+
+func (a *Ads) validateGetAll(filter entity.AdsFilter) error {
+	if filter.Limit <= 0 || filter.Limit > feedMaxLimit {
+		return apperr.NewFieldError("limit", fmt.Sprintf("must be between 1 and %d", feedMaxLimit))
+	}
+	if filter.Offset < 0 {
+		return apperr.NewFieldError("offset", "must not be negative")
+	}
+	if filter.PriceMin < 0 {
+		return apperr.NewFieldError("min", "must not be negative")
+	}
+	if filter.PriceMax < 0 {
+		return apperr.NewFieldError("max", "must not be negative")
+	}
+	if filter.PriceMax > 0 && filter.PriceMax < filter.PriceMin {
+		return apperr.NewFieldError("max", "must not be less than min")
+	}
+	if filter.PricePreset != "" {
+		if _, ok := entity.PricePresets[filter.PricePreset]; !ok {
+			return apperr.NewFieldError("price", "must be budget, mid or premium")
+		}
+	}
+	if !filter.CreatedAfter.IsZero() && !filter.CreatedBefore.IsZero() && !filter.CreatedBefore.After(filter.CreatedAfter) {
+		return apperr.NewFieldError("created_before", "must be later than created_after")
+	}
+
+	if len(filter.Tags) > entity.MaxTagsPerAd {
+		return apperr.NewFieldError("tags", fmt.Sprintf("at most %d tags allowed", entity.MaxTagsPerAd))
+	}
+
+	if len(filter.Sort) > feedMaxSortKeys {
+		return apperr.NewFieldError("sort", fmt.Sprintf("at most %d keys allowed", feedMaxSortKeys))
+	}
+	seen := make(map[string]bool, len(filter.Sort))
+	for _, key := range filter.Sort {
+		if !feedSortFields[key.Field] {
+			return apperr.NewFieldError("sort", fmt.Sprintf("unknown field %q", key.Field))
+		}
+		if seen[key.Field] {
+			return apperr.NewFieldError("sort", fmt.Sprintf("duplicate field %q", key.Field))
+		}
+		seen[key.Field] = true
+	}
+	return nil
+}
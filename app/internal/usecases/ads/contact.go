@@ -0,0 +1,53 @@
+package ads
+
+import (
+	"fmt"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"time"
+)
+
+// RevealContact — полный телефон продавца. Каждый показ сохраняется для статистики владельца,
+// частота показов ограничена на пользователя, чтобы номера нельзя было собрать массово.
+// Свой номер владелец видит без ограничений и без учёта в статистике.
+func (a *Ads) RevealContact(adId, userId string) (string, error) {
+	contact, err := a.repo.GetContact(adId)
+	if err != nil {
+		return "", fmt.Errorf("get contact failed: %w", err)
+	}
+	if !contact.Published && contact.AuthorId != userId {
+		return "", apperr.ErrAdsNotFound
+	}
+	if contact.Phone == "" {
+		return "", apperr.ErrContactNotSet
+	}
+	if contact.AuthorId == userId {
+		return contact.Phone, nil
+	}
+
+	if ok, wait := a.reveals.Allow(userId); !ok {
+		return "", fmt.Errorf("%w: retry in %s", apperr.ErrRevealRateLimit, wait.Round(time.Second))
+	}
+
+	if err := a.repo.SaveReveal(adId, userId, time.Now().UTC()); err != nil {
+		return "", fmt.Errorf("save reveal failed: %w", err)
+	}
+	return contact.Phone, nil
+}
+
+// ContactStats — статистика показов телефона, доступна только владельцу объявления
+func (a *Ads) ContactStats(adId, userId string) (entity.RevealStats, error) {
+	contact, err := a.repo.GetContact(adId)
+	if err != nil {
+		return entity.RevealStats{}, fmt.Errorf("get contact failed: %w", err)
+	}
+	if contact.AuthorId != userId {
+		return entity.RevealStats{}, apperr.ErrForbidden
+	}
+
+	stats, err := a.repo.GetRevealStats(adId)
+	if err != nil {
+		return entity.RevealStats{}, fmt.Errorf("get reveal stats failed: %w", err)
+	}
+	return stats, nil
+}
@@ -1,11 +1,32 @@
 package ads
 
-import "market/app/internal/entity"
+import (
+	"market/app/internal/entity"
+	"market/app/internal/events"
+	"time"
+)
 
 type AdsRepo interface {
 	Create(ad entity.Ad) (entity.Ad, error)
-	GetAll(limit, offset int, sortBy, order string, priceMin, priceMax float64) ([]entity.Ad, error)
+	GetAll(filter entity.AdsFilter) ([]entity.Ad, error)
 	GetById(adId string) (entity.Ad, error)
 	Delete(userId, adId string) error
 	GetAuthorName(userId string) (string, error)
+	GetSimilar(ad entity.Ad, limit int) ([]entity.Ad, error)
+	PublishDue(now time.Time, limit int) ([]entity.Ad, error)
+	GetTags(adId string) ([]string, error)
+	GetTagsByAds(adIds []string) (map[string][]string, error)
+	GetAuthors(ids []string) ([]entity.User, error)
+	SellUnits(adId string, units int) (entity.Ad, error)
+	GetContact(adId string) (entity.AdContact, error)
+	SaveReveal(adId, userId string, at time.Time) error
+	GetRevealStats(adId string) (entity.RevealStats, error)
+}
+
+type Publisher interface {
+	Publish(e events.Event)
+}
+
+type RateLimiter interface {
+	Allow(key string) (bool, time.Duration)
 }
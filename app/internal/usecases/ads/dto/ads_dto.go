@@ -2,6 +2,7 @@ package dto
 
 import (
 	entity2 "market/app/internal/entity"
+	"time"
 )
 
 type AdResponse struct {
@@ -9,10 +10,15 @@ type AdResponse struct {
 	Title       string
 	Description string
 	Price       float64
+	CreatedAt   time.Time
 	Author      string
 	AuthorID    string
 	IsOwner     bool
 	Images      []string
+	Published   bool
+	PublishAt   time.Time
+	Tags        []string
+	Quantity    int
 }
 
 type AdDetailed struct {
@@ -20,4 +26,6 @@ type AdDetailed struct {
 	Author  string
 	Images  []entity2.AdImage
 	IsOwner bool
+	// Phone — замаскированный телефон продавца, полный номер отдаёт только RevealContact
+	Phone string
 }
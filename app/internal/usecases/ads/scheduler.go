@@ -0,0 +1,47 @@
+package ads
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const publishBatchSize = 100
+
+// PublishDue — публикует отложенные объявления, время которых наступило,
+// и рассылает по ним события, как при обычном создании
+func (a *Ads) PublishDue() (int, error) {
+	ads, err := a.repo.PublishDue(time.Now().UTC(), publishBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, ad := range ads {
+		a.publishCreated(ad)
+	}
+	return len(ads), nil
+}
+
+// RunScheduler — раз в interval публикует наступившие объявления, пока не отменён ctx
+func (a *Ads) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				n, err := a.PublishDue()
+				if err != nil {
+					log.Println("scheduled publishing failed:", err)
+					break
+				}
+				// пачка заполнена целиком — возможно, есть ещё
+				if n < publishBatchSize {
+					break
+				}
+			}
+		}
+	}
+}
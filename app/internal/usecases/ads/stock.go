@@ -0,0 +1,29 @@
+package ads
+
+import (
+	"fmt"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+)
+
+// SellUnits — продавец отмечает проданные единицы товара. Остаток уменьшается атомарно,
+// при нуле объявление считается распроданным и новые заказы по нему не принимаются.
+func (a *Ads) SellUnits(adId, userId string, units int) (entity.Ad, error) {
+	if units < 1 {
+		return entity.Ad{}, apperr.ErrInvalidUnits
+	}
+
+	ad, err := a.repo.GetById(adId)
+	if err != nil {
+		return entity.Ad{}, fmt.Errorf("get ad by id failed: %w", err)
+	}
+	if ad.AuthorId != userId {
+		return entity.Ad{}, apperr.ErrForbidden
+	}
+
+	sold, err := a.repo.SellUnits(adId, units)
+	if err != nil {
+		return entity.Ad{}, fmt.Errorf("sell units failed: %w", err)
+	}
+	return sold, nil
+}
@@ -1,10 +1,39 @@
 package img
 
-import "market/app/internal/entity"
+import (
+	"market/app/internal/entity"
+	"market/app/internal/events"
+	"market/app/internal/storage"
+)
 
 type Img interface {
-	Create(img entity.AdImage) (entity.AdImage, error)
+	Create(img entity.AdImage, maxPerAd int, quotaBytes int64, store func() error, discard func()) (entity.AdImage, error)
 	GetImages(adId string) ([]entity.AdImage, error)
 	GetImageById(id string) (entity.AdImage, error)
-	Exists(adId string) (bool, error)
+	GetImagesByAds(adIds []string) ([]entity.AdImage, error)
+	GetAdState(adId string) (string, bool, error)
+	GetAdAuthorId(adId string) (string, error)
+	Delete(imgID string, cleanup func() error) error
+	Reorder(adId string, imgIds []string) error
+	StorageUsage(userId string) (int64, int, error)
+	LegacyImages() ([]entity.AdImage, error)
+	BlobReferenced(hash string) (bool, error)
+	ReleaseBlob(hash string, remove func() error) (bool, error)
+	SetImagesPrivate(adId string, private bool) error
+	BlobPrivate(key, hash string) (bool, error)
+	HasPrivateImages() (bool, error)
+}
+
+// BlobStorage — где лежат файлы изображений: локальный диск или S3-совместимое хранилище
+type BlobStorage interface {
+	Put(key string, data []byte, contentType string) error
+	Get(key string) (storage.Object, error)
+	Delete(key string) error
+	List() ([]storage.Info, error)
+	// URL — адрес, по которому файл увидит клиент
+	URL(key string) string
+}
+
+type Publisher interface {
+	Publish(e events.Event)
 }
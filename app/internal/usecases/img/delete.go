@@ -0,0 +1,111 @@
+package img
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"market/app/internal/events"
+	"net/http"
+	"path"
+)
+
+// DeleteImage — удаляет изображение объявления вместе с файлами, только для владельца.
+// Файлы удаляются внутри транзакции, а их содержимое держится в памяти: если запись
+// удалить не удалось, файлы записываются обратно, и ссылок на пустоту не остаётся.
+func (i *ImgUsecase) DeleteImage(imgId, userId string) error {
+	img, err := i.repo.GetImageById(imgId)
+	if err != nil {
+		return err
+	}
+
+	authorId, published, err := i.repo.GetAdState(img.AdId)
+	if err != nil {
+		return err
+	}
+	if authorId != userId {
+		return apperr.ErrForbidden
+	}
+
+	removed := make(map[string][]byte)
+	restore := func() error {
+		for key, data := range removed {
+			if err := i.blobs.Put(key, data, http.DetectContentType(data)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err = i.repo.Delete(img.Id, func() error {
+		for _, key := range imageKeys(img) {
+			data, err := i.readBlob(key)
+			if err != nil {
+				// файла уже нет — удалять нечего
+				if errors.Is(err, apperr.ErrBlobNotFound) {
+					continue
+				}
+				return err
+			}
+			if err := i.blobs.Delete(key); err != nil {
+				return fmt.Errorf("delete image file failed: %w", err)
+			}
+			removed[key] = data
+		}
+		return nil
+	})
+	if err != nil {
+		if rerr := restore(); rerr != nil {
+			return fmt.Errorf("restore image file failed: %w (delete: %w)", rerr, err)
+		}
+		return fmt.Errorf("delete image failed: %w", err)
+	}
+
+	if published {
+		i.events.Publish(events.Event{
+			Type:   events.ImageDeleted,
+			AdId:   img.AdId,
+			UserId: authorId,
+			Image:  &img,
+		})
+	}
+	return nil
+}
+
+func (i *ImgUsecase) readBlob(key string) ([]byte, error) {
+	obj, err := i.blobs.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Body.Close()
+	return io.ReadAll(obj.Body)
+}
+
+// imageKeys — ключи файлов оригинала и всех копий в хранилище
+func imageKeys(img entity.AdImage) []string {
+	keys := map[string]bool{keyByURL(img.ImageURL): true}
+	for _, url := range img.Variants {
+		keys[keyByURL(url)] = true
+	}
+
+	res := make([]string, 0, len(keys))
+	for key := range keys {
+		res = append(res, key)
+	}
+	return res
+}
+
+func keyByURL(url string) string {
+	return path.Base(url)
+}
+
+// removeFiles — удаляет файлы, записанные для загрузки, которая не удалась
+func (i *ImgUsecase) removeFiles(files []blobFile) {
+	for _, f := range files {
+		if err := i.blobs.Delete(f.key); err != nil {
+			log.Println("remove image file failed:", err)
+		}
+	}
+}
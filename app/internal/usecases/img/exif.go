@@ -0,0 +1,167 @@
+package img
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
+)
+
+const exifOrientationTag = 0x0112
+
+// orientation — значение тега Orientation (1–8) из EXIF в JPEG (сегмент APP1)
+// или PNG (чанк eXIf). Если тега нет или данные повреждены — 1, без поворота.
+func orientation(data []byte, contentType string) int {
+	var tiff []byte
+	switch contentType {
+	case "image/jpeg":
+		tiff = jpegExif(data)
+	case "image/png":
+		tiff = pngExif(data)
+	}
+	return tiffOrientation(tiff)
+}
+
+func jpegExif(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil
+		}
+		marker := data[i+1]
+		// маркеры без длины
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD8) {
+			i += 2
+			continue
+		}
+		// дальше идут сами данные изображения, метаданные раньше
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+		if size < 2 || i+2+size > len(data) {
+			return nil
+		}
+		segment := data[i+4 : i+2+size]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		i += 2 + size
+	}
+	return nil
+}
+
+func pngExif(data []byte) []byte {
+	const signature = "\x89PNG\r\n\x1a\n"
+	if !bytes.HasPrefix(data, []byte(signature)) {
+		return nil
+	}
+	for i := len(signature); i+8 <= len(data); {
+		size := int(binary.BigEndian.Uint32(data[i:]))
+		kind := string(data[i+4 : i+8])
+		if i+8+size > len(data) {
+			return nil
+		}
+		if kind == "eXIf" {
+			return data[i+8 : i+8+size]
+		}
+		if kind == "IEND" {
+			return nil
+		}
+		// длина, тип, данные и CRC
+		i += 12 + size
+	}
+	return nil
+}
+
+// tiffOrientation — ищет тег Orientation в первом IFD блока TIFF
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < count; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != exifOrientationTag {
+			continue
+		}
+		// тип SHORT, значение лежит прямо в записи
+		if order.Uint16(tiff[entry+2:]) != 3 {
+			return 1
+		}
+		if v := int(order.Uint16(tiff[entry+8:])); v >= 1 && v <= 8 {
+			return v
+		}
+		return 1
+	}
+	return 1
+}
+
+// orient — поворачивает и отражает изображение так, как его показывают
+// просмотрщики, учитывающие EXIF: после перекодирования тега уже не будет.
+// Пиксели переносятся сразу в итоговый буфер, без промежуточной копии.
+func orient(src image.Image, o int) image.Image {
+	if o <= 1 || o > 8 {
+		return src
+	}
+
+	b := src.Bounds()
+	w, h := float64(b.Dx()), float64(b.Dy())
+	dw, dh := b.Dx(), b.Dy()
+	if o >= 5 {
+		dw, dh = dh, dw
+	}
+
+	// s2d — куда попадает точка исходника (отсчёт от b.Min): x' = a*x + b*y + c, y' = d*x + e*y + f.
+	// Коэффициенты целые, поэтому центры пикселей переходят точно в центры.
+	var m f64.Aff3
+	switch o {
+	case 2: // отражение по горизонтали
+		m = f64.Aff3{-1, 0, w, 0, 1, 0}
+	case 3: // поворот на 180°
+		m = f64.Aff3{-1, 0, w, 0, -1, h}
+	case 4: // отражение по вертикали
+		m = f64.Aff3{1, 0, 0, 0, -1, h}
+	case 5: // транспонирование
+		m = f64.Aff3{0, 1, 0, 1, 0, 0}
+	case 6: // поворот на 90° по часовой
+		m = f64.Aff3{0, -1, h, 1, 0, 0}
+	case 7: // поперечное транспонирование
+		m = f64.Aff3{0, -1, h, -1, 0, w}
+	case 8: // поворот на 90° против часовой
+		m = f64.Aff3{0, 1, 0, -1, 0, w}
+	}
+	mx, my := float64(b.Min.X), float64(b.Min.Y)
+	m[2] -= m[0]*mx + m[1]*my
+	m[5] -= m[3]*mx + m[4]*my
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	draw.NearestNeighbor.Transform(dst, m, src, b, draw.Src, nil)
+	return dst
+}
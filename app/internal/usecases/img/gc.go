@@ -0,0 +1,177 @@
+package img
+
+import (
+	"context"
+	"encoding/hex"
+	"log"
+	"market/app/internal/storage"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GCReport — итог сборки мусора. При DryRun файлы не удаляются, а Removed
+// и FreedBytes показывают, что было бы удалено.
+type GCReport struct {
+	DryRun     bool
+	Scanned    int
+	Removed    []string
+	FreedBytes int64
+}
+
+// CollectGarbage — удаляет из хранилища файлы, на которые не ссылается ни одно
+// изображение: остатки удалённых объявлений и неудачных загрузок. Файлы моложе
+// grace не трогаются — они могут принадлежать загрузке, которая ещё идёт.
+func (i *ImgUsecase) CollectGarbage(grace time.Duration, dryRun bool) (GCReport, error) {
+	report := GCReport{DryRun: dryRun}
+
+	objects, err := i.blobs.List()
+	if err != nil {
+		return report, err
+	}
+	legacy, err := i.legacyKeys()
+	if err != nil {
+		return report, err
+	}
+
+	cutoff := time.Now().Add(-grace)
+	byHash := make(map[string][]storage.Info)
+	for _, obj := range objects {
+		report.Scanned++
+		if obj.ModTime.After(cutoff) {
+			continue
+		}
+		if hash, ok := contentHash(obj.Key); ok {
+			byHash[hash] = append(byHash[hash], obj)
+			continue
+		}
+		// чужие файлы (.keep, объекты других приложений в том же бакете) не трогаем
+		if !legacyName(obj.Key) || legacy[obj.Key] {
+			continue
+		}
+		// файл со старым именем, на который никто не ссылается: новые загрузки
+		// таких имён не создают, поэтому удалять можно без блокировки
+		if !dryRun {
+			if err := i.blobs.Delete(obj.Key); err != nil {
+				log.Println("gc: remove file failed:", obj.Key, err)
+				continue
+			}
+		}
+		report.add(obj)
+	}
+
+	for hash, files := range byHash {
+		released, err := i.releaseBlob(hash, files, dryRun)
+		if err != nil {
+			log.Println("gc: release blob failed:", hash, err)
+			continue
+		}
+		if released {
+			for _, f := range files {
+				report.add(f)
+			}
+		}
+	}
+	return report, nil
+}
+
+// RunGC — раз в interval собирает мусор, пока не отменён ctx
+func (i *ImgUsecase) RunGC(ctx context.Context, interval, grace time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := i.CollectGarbage(grace, false)
+			if err != nil {
+				log.Println("gc failed:", err)
+				continue
+			}
+			if len(report.Removed) > 0 {
+				log.Printf("gc: removed %d files, freed %d bytes", len(report.Removed), report.FreedBytes)
+			}
+		}
+	}
+}
+
+func (i *ImgUsecase) releaseBlob(hash string, files []storage.Info, dryRun bool) (bool, error) {
+	if dryRun {
+		referenced, err := i.repo.BlobReferenced(hash)
+		return !referenced, err
+	}
+	return i.repo.ReleaseBlob(hash, func() error {
+		for _, f := range files {
+			if err := i.blobs.Delete(f.Key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// legacyKeys — файлы изображений, загруженных до дедупликации
+func (i *ImgUsecase) legacyKeys() (map[string]bool, error) {
+	images, err := i.repo.LegacyImages()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]bool)
+	for _, img := range images {
+		for _, key := range imageKeys(img) {
+			keys[key] = true
+		}
+	}
+	return keys, nil
+}
+
+// contentHash — hash из имени файла вида <sha256>.jpg или <sha256>_thumb.jpg
+func contentHash(key string) (string, bool) {
+	base, ok := imageBase(key)
+	// hex.EncodeToString пишет в нижнем регистре, другие имена — не наши
+	if !ok || len(base) != 64 || strings.ToLower(base) != base {
+		return "", false
+	}
+	if _, err := hex.DecodeString(base); err != nil {
+		return "", false
+	}
+	return base, true
+}
+
+// legacyName — имя файла, загруженного до дедупликации: <uuid>.jpg или <uuid>_thumb.jpg
+func legacyName(key string) bool {
+	base, ok := imageBase(key)
+	return ok && uuid.Validate(base) == nil
+}
+
+// imageBase — имя файла без расширения и размера копии, если файл назван так,
+// как называет его приложение: без каталогов, с расширением изображения
+func imageBase(key string) (string, bool) {
+	if strings.ContainsRune(key, '/') || strings.HasPrefix(key, ".") {
+		return "", false
+	}
+	ext := path.Ext(key)
+	switch ext {
+	case ".jpg", ".jpeg", ".png":
+	default:
+		return "", false
+	}
+
+	base := strings.TrimSuffix(key, ext)
+	for _, v := range variantSizes {
+		if name, ok := strings.CutSuffix(base, "_"+v.name); ok {
+			return name, true
+		}
+	}
+	return base, true
+}
+
+func (r *GCReport) add(obj storage.Info) {
+	r.Removed = append(r.Removed, obj.Key)
+	r.FreedBytes += obj.Size
+}
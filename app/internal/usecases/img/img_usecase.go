@@ -1,114 +1,189 @@
 package img
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"market/app/internal/apperr"
+	"market/app/internal/cache"
 	"market/app/internal/entity"
+	"market/app/internal/events"
 	"market/app/internal/utils"
-	"os"
-	"path/filepath"
 
 	"time"
 )
 
 type ImgUsecase struct {
-	repo Img
+	repo    Img
+	events  Publisher
+	blobs   BlobStorage
+	limits  Limits
+	signing Signing
+	private *cache.TTL[string, bool]
 }
 
-func NewImgUsecase(repo Img) *ImgUsecase {
-	return &ImgUsecase{repo}
+// Limits — сколько изображений можно в одном объявлении и сколько байт
+// у одного пользователя; 0 — без ограничения
+type Limits struct {
+	MaxPerAd   int
+	QuotaBytes int64
 }
 
-func (i *ImgUsecase) AddImage(adId string, data []byte, ext string) (entity.AdImage, error) {
-	exists, err := i.repo.Exists(adId)
+func NewImgUsecase(repo Img, events Publisher, blobs BlobStorage, limits Limits, signing Signing) *ImgUsecase {
+	return &ImgUsecase{
+		repo:    repo,
+		events:  events,
+		blobs:   blobs,
+		limits:  limits,
+		signing: signing,
+		private: cache.NewTTL[string, bool](privacyTTL),
+	}
+}
+
+// AddImage — сохраняет изображение объявления; загружать может только автор объявления
+func (i *ImgUsecase) AddImage(adId, userId string, data []byte, ext string) (entity.AdImage, error) {
+	authorId, published, err := i.repo.GetAdState(adId)
 	if err != nil {
 		return entity.AdImage{}, err
 	}
-	if !exists {
-		return entity.AdImage{}, apperr.ErrAddNotFound
+	if authorId != userId {
+		return entity.AdImage{}, apperr.ErrForbidden
 	}
 
-	imgId, err := utils.GenerateUUID()
+	src, data, err := normalize(data, ext)
 	if err != nil {
 		return entity.AdImage{}, err
 	}
-	filename, err := utils.GenerateUUID()
+
+	imgId, err := utils.GenerateUUID()
 	if err != nil {
 		return entity.AdImage{}, err
-
 	}
-	ext = i.getExt(ext)
-	filename += ext
-	imageURL, err := i.saveFile(filename, data)
-	if err != nil {
+	// файлы называются по содержимому: повторная загрузка того же фото их не дублирует
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
 
+	files, variants, size, err := i.renderVariants(hash, i.getExt(ext), ext, data, src)
+	if err != nil {
 		return entity.AdImage{}, err
 	}
 
 	res := entity.AdImage{
 		Id:        imgId,
 		AdId:      adId,
-		ImageURL:  imageURL,
+		ImageURL:  variants[entity.ImageOriginal],
 		CreatedAt: time.Now().UTC(),
+		Variants:  variants,
+		Size:      size,
+		Hash:      hash,
 	}
 
-	res, err = i.repo.Create(res)
+	// лимиты проверяются в той же транзакции, что и вставка; файлы пишутся
+	// там же и только если такого содержимого ещё нет
+	res, err = i.repo.Create(res, i.limits.MaxPerAd, i.limits.QuotaBytes,
+		func() error { return i.storeFiles(files, ext) },
+		func() { i.removeFiles(files) },
+	)
 	if err != nil {
 		return entity.AdImage{}, err
 	}
-	return res, nil
+
+	// подписчики потока анонимны: об отложенном объявлении они узнают при публикации
+	if published {
+		i.events.Publish(events.Event{
+			Type:   events.ImageAdded,
+			AdId:   adId,
+			UserId: authorId,
+			Image:  &res,
+		})
+	}
+	return i.signIfPrivate(res), nil
 }
 
-func (i *ImgUsecase) saveFile(filename string, data []byte) (string, error) {
-	wd, err := os.Getwd()
+// StorageUsage — занятое пользователем место и действующие ограничения
+func (i *ImgUsecase) StorageUsage(userId string) (entity.StorageUsage, error) {
+	used, images, err := i.repo.StorageUsage(userId)
 	if err != nil {
-		return "", err
-	}
-	uploadPath := filepath.Join(wd, "..", "static", "upload")
-	//uploadPath := filepath.Join(wd, "static", "upload")
+		return entity.StorageUsage{}, err
+	}
+	return entity.StorageUsage{
+		UsedBytes:      used,
+		QuotaBytes:     i.limits.QuotaBytes,
+		Images:         images,
+		MaxImagesPerAd: i.limits.MaxPerAd,
+	}, nil
+}
 
-	if err := os.MkdirAll(uploadPath, 0755); err != nil {
-		return "", err
+// GetImages — изображения объявления для viewerId; закрытые анонимному
+// пользователю не отдаются (apperr.ErrPrivateImages)
+func (i *ImgUsecase) GetImages(adId, viewerId string) ([]entity.AdImage, error) {
+	if err := i.checkVisible(adId, viewerId); err != nil {
+		return nil, err
 	}
 
-	filepath := filepath.Join(uploadPath, filename)
-	file, err := os.Create(filepath)
+	res, err := i.repo.GetImages(adId)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	defer file.Close()
-
-	if _, err := file.Write(data); err != nil {
-		return "", err
+	// закрытость задаётся на всё объявление сразу
+	if len(res) > 0 && res[0].Private && viewerId == "" {
+		return nil, apperr.ErrPrivateImages
 	}
-
-	publicPath := "/static/upload/" + filename
-	return publicPath, nil
+	return i.view(res, viewerId), nil
 }
 
-func (i *ImgUsecase) GetImages(adId string) ([]entity.AdImage, error) {
-	exists, err := i.repo.Exists(adId)
+// ViewImages — изображения объявления в карточках и ленте: закрытые анонимному
+// пользователю просто не показываются
+func (i *ImgUsecase) ViewImages(adId, viewerId string) ([]entity.AdImage, error) {
+	res, err := i.repo.GetImages(adId)
 	if err != nil {
 		return nil, err
 	}
-	if !exists {
-		return nil, apperr.ErrAddNotFound
-	}
+	return i.view(res, viewerId), nil
+}
 
-	res, err := i.repo.GetImages(adId)
+// ImagesByAds — изображения нескольких объявлений для viewerId, сгруппированные по adId
+func (i *ImgUsecase) ImagesByAds(adIds []string, viewerId string) (map[string][]entity.AdImage, error) {
+	images, err := i.repo.GetImagesByAds(adIds)
 	if err != nil {
 		return nil, err
 	}
+
+	res := make(map[string][]entity.AdImage, len(adIds))
+	for _, img := range i.view(images, viewerId) {
+		res[img.AdId] = append(res[img.AdId], img)
+	}
 	return res, nil
 }
 
-func (i *ImgUsecase) GetImageById(
-	id string) (entity.AdImage, error) {
-
+func (i *ImgUsecase) GetImageById(id, viewerId string) (entity.AdImage, error) {
 	res, err := i.repo.GetImageById(id)
 	if err != nil {
 		return entity.AdImage{}, err
 	}
-	return res, nil
+	if err := i.checkVisible(res.AdId, viewerId); err != nil {
+		if errors.Is(err, apperr.ErrAddNotFound) {
+			return entity.AdImage{}, apperr.ErrImgNotFound
+		}
+		return entity.AdImage{}, err
+	}
+	if res.Private && viewerId == "" {
+		return entity.AdImage{}, apperr.ErrPrivateImages
+	}
+	return i.signIfPrivate(res), nil
+}
+
+// checkVisible — отложенное объявление до публикации видит только автор,
+// для остальных его нет (apperr.ErrAddNotFound)
+func (i *ImgUsecase) checkVisible(adId, viewerId string) error {
+	authorId, published, err := i.repo.GetAdState(adId)
+	if err != nil {
+		return err
+	}
+	if !published && authorId != viewerId {
+		return apperr.ErrAddNotFound
+	}
+	return nil
 }
 
 func (i *ImgUsecase) getExt(ext string) string {
@@ -0,0 +1,390 @@
+package img
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"market/app/internal/events"
+	"market/app/internal/storage"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAdId    = "7c0d2d4e-4a57-4c43-9d0e-6a4c2f3b8e11"
+	testOwnerId = "1f9a7b52-3c1e-4b8d-a0f4-5e2d9c6b7a30"
+	testOtherId = "9b3e5c71-2d4f-4a6e-8c1b-0f7e6d5a4b92"
+)
+
+// fakeRepo — репозиторий изображений в памяти; методы, которые тесты не вызывают,
+// достаются от nil-интерфейса и паникуют
+type fakeRepo struct {
+	Img
+	ads    map[string]fakeAd
+	images []entity.AdImage
+}
+
+type fakeAd struct {
+	authorId  string
+	published bool
+}
+
+func (r *fakeRepo) GetAdState(adId string) (string, bool, error) {
+	ad, ok := r.ads[adId]
+	if !ok {
+		return "", false, apperr.ErrAddNotFound
+	}
+	return ad.authorId, ad.published, nil
+}
+
+func (r *fakeRepo) Create(img entity.AdImage, maxPerAd int, quotaBytes int64, store func() error, discard func()) (entity.AdImage, error) {
+	if err := store(); err != nil {
+		discard()
+		return entity.AdImage{}, err
+	}
+	r.images = append(r.images, img)
+	return img, nil
+}
+
+func (r *fakeRepo) GetImageById(id string) (entity.AdImage, error) {
+	for _, img := range r.images {
+		if img.Id == id {
+			return img, nil
+		}
+	}
+	return entity.AdImage{}, apperr.ErrImgNotFound
+}
+
+// Delete — как у настоящего репозитория при последней ссылке на файлы: запись
+// удаляется, только если cleanup прошёл
+func (r *fakeRepo) Delete(imgID string, cleanup func() error) error {
+	for n, img := range r.images {
+		if img.Id != imgID {
+			continue
+		}
+		if err := cleanup(); err != nil {
+			return err
+		}
+		r.images = slices.Delete(r.images, n, n+1)
+		return nil
+	}
+	return apperr.ErrImgNotFound
+}
+
+// memBlobs — BlobStorage в памяти; с public ссылки ведут на внешний адрес, как у S3 с S3_PUBLIC_URL
+type memBlobs struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	public  string
+}
+
+func newMemBlobs() *memBlobs {
+	return &memBlobs{objects: make(map[string][]byte)}
+}
+
+func (m *memBlobs) Put(key string, data []byte, contentType string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = bytes.Clone(data)
+	return nil
+}
+
+func (m *memBlobs) Get(key string) (storage.Object, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.objects[key]
+	if !ok {
+		return storage.Object{}, apperr.ErrBlobNotFound
+	}
+	return storage.Object{Body: nopCloser{bytes.NewReader(data)}, Size: int64(len(data))}, nil
+}
+
+func (m *memBlobs) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, key)
+	return nil
+}
+
+func (m *memBlobs) List() ([]storage.Info, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := make([]storage.Info, 0, len(m.objects))
+	for key, data := range m.objects {
+		res = append(res, storage.Info{Key: key, Size: int64(len(data))})
+	}
+	return res, nil
+}
+
+func (m *memBlobs) URL(key string) string {
+	if m.public != "" {
+		return m.public + "/" + key
+	}
+	return storage.PublicPrefix + key
+}
+
+func (m *memBlobs) keys() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := make([]string, 0, len(m.objects))
+	for key := range m.objects {
+		res = append(res, key)
+	}
+	sort.Strings(res)
+	return res
+}
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
+
+type recordingPublisher struct {
+	events []events.Event
+}
+
+func (p *recordingPublisher) Publish(e events.Event) {
+	p.events = append(p.events, e)
+}
+
+func newTestUsecase(repo *fakeRepo, blobs BlobStorage, pub Publisher) *ImgUsecase {
+	return NewImgUsecase(repo, pub, blobs, Limits{}, Signing{Secret: []byte("test"), TTL: time.Hour})
+}
+
+// testPNG — однотонная картинка w×h в PNG
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	src := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			src.Set(x, y, color.RGBA{R: 200, G: 80, B: 40, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestAddImage(t *testing.T) {
+	tests := []struct {
+		name       string
+		adId       string
+		userId     string
+		wantErr    error
+		wantStored bool
+	}{
+		{name: "owner", adId: testAdId, userId: testOwnerId, wantStored: true},
+		{name: "not owner", adId: testAdId, userId: testOtherId, wantErr: apperr.ErrForbidden},
+		{name: "missing ad", adId: "00000000-0000-0000-0000-000000000000", userId: testOwnerId, wantErr: apperr.ErrAddNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepo{ads: map[string]fakeAd{testAdId: {authorId: testOwnerId, published: true}}}
+			blobs := newMemBlobs()
+			pub := &recordingPublisher{}
+			uc := newTestUsecase(repo, blobs, pub)
+
+			img, err := uc.AddImage(tt.adId, tt.userId, testPNG(t, 300, 200), "image/png")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddImage() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !tt.wantStored {
+				if len(repo.images) != 0 || len(blobs.keys()) != 0 || len(pub.events) != 0 {
+					t.Fatalf("rejected upload left images=%d files=%v events=%d", len(repo.images), blobs.keys(), len(pub.events))
+				}
+				return
+			}
+
+			if img.AdId != tt.adId || img.Hash == "" {
+				t.Fatalf("unexpected image %+v", img)
+			}
+			for _, name := range []string{entity.ImageThumb, entity.ImageMedium, entity.ImageOriginal} {
+				if img.Variants[name] == "" {
+					t.Errorf("variant %q is missing", name)
+				}
+			}
+			want := []string{img.Hash + ".png", img.Hash + "_thumb.png"}
+			if got := blobs.keys(); !slices.Equal(got, want) {
+				t.Errorf("stored files = %v, want %v", got, want)
+			}
+			if len(pub.events) != 1 || pub.events[0].Type != events.ImageAdded {
+				t.Errorf("events = %+v, want one image_added", pub.events)
+			}
+		})
+	}
+}
+
+func TestImageFilesLiveInBlobStorage(t *testing.T) {
+	repo := &fakeRepo{ads: map[string]fakeAd{testAdId: {authorId: testOwnerId, published: true}}}
+	blobs := newMemBlobs()
+	blobs.public = "https://cdn.example.com"
+	uc := newTestUsecase(repo, blobs, &recordingPublisher{})
+
+	img, err := uc.AddImage(testAdId, testOwnerId, testPNG(t, 1000, 500), "image/png")
+	if err != nil {
+		t.Fatalf("AddImage() error = %v", err)
+	}
+
+	// ссылки строит хранилище, а не usecase
+	for name, u := range img.Variants {
+		if !strings.HasPrefix(u, "https://cdn.example.com/"+img.Hash) {
+			t.Errorf("variant %s URL = %q, want it in the storage public URL", name, u)
+		}
+	}
+	want := []string{img.Hash + ".png", img.Hash + "_medium.png", img.Hash + "_thumb.png"}
+	if got := blobs.keys(); !slices.Equal(got, want) {
+		t.Fatalf("stored files = %v, want %v", got, want)
+	}
+
+	if err := uc.DeleteImage(img.Id, testOwnerId); err != nil {
+		t.Fatalf("DeleteImage() error = %v", err)
+	}
+	if got := blobs.keys(); len(got) != 0 {
+		t.Errorf("files left after delete: %v", got)
+	}
+	if len(repo.images) != 0 {
+		t.Errorf("images left after delete: %d", len(repo.images))
+	}
+}
+
+// privacyRepo — объявление testAdId и флаг закрытых изображений в базе
+type privacyRepo struct {
+	fakeRepo
+	private bool
+}
+
+func (r *privacyRepo) GetAdAuthorId(adId string) (string, error) {
+	ad, ok := r.ads[adId]
+	if !ok {
+		return "", apperr.ErrAddNotFound
+	}
+	return ad.authorId, nil
+}
+
+func (r *privacyRepo) SetImagesPrivate(adId string, private bool) error {
+	r.private = private
+	return nil
+}
+
+func (r *privacyRepo) GetImages(adId string) ([]entity.AdImage, error) {
+	return nil, nil
+}
+
+func (r *privacyRepo) HasPrivateImages() (bool, error) {
+	return r.private, nil
+}
+
+func TestPrivateImagesNeedAppServedFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		public  string
+		wantErr error
+	}{
+		{name: "files served by the app", public: ""},
+		{name: "public bucket url", public: "https://cdn.example.com", wantErr: apperr.ErrPrivateUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &privacyRepo{fakeRepo: fakeRepo{ads: map[string]fakeAd{testAdId: {authorId: testOwnerId, published: true}}}}
+			blobs := newMemBlobs()
+			blobs.public = tt.public
+			uc := NewImgUsecase(repo, &recordingPublisher{}, blobs, Limits{}, Signing{Secret: []byte("test"), TTL: time.Hour})
+
+			_, err := uc.SetImagesPrivate(testAdId, testOwnerId, true)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetImagesPrivate(true) error = %v, want %v", err, tt.wantErr)
+			}
+			if want := tt.wantErr == nil; repo.private != want {
+				t.Errorf("images private = %v, want %v", repo.private, want)
+			}
+
+			// открыть можно всегда
+			if _, err := uc.SetImagesPrivate(testAdId, testOwnerId, false); err != nil {
+				t.Errorf("SetImagesPrivate(false) error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckPrivacy(t *testing.T) {
+	repo := &privacyRepo{private: true}
+	blobs := newMemBlobs()
+	uc := NewImgUsecase(repo, &recordingPublisher{}, blobs, Limits{}, Signing{})
+	if err := uc.CheckPrivacy(); err != nil {
+		t.Errorf("CheckPrivacy() with app-served files error = %v", err)
+	}
+
+	blobs.public = "https://cdn.example.com"
+	if err := uc.CheckPrivacy(); !errors.Is(err, apperr.ErrPrivateUnavailable) {
+		t.Errorf("CheckPrivacy() with private ads in a public bucket error = %v, want %v", err, apperr.ErrPrivateUnavailable)
+	}
+
+	repo.private = false
+	if err := uc.CheckPrivacy(); err != nil {
+		t.Errorf("CheckPrivacy() without private ads error = %v", err)
+	}
+}
+
+// gcRepo — ни на один файл нет ссылок
+type gcRepo struct {
+	fakeRepo
+}
+
+func (r *gcRepo) LegacyImages() ([]entity.AdImage, error) {
+	return nil, nil
+}
+
+func (r *gcRepo) ReleaseBlob(hash string, remove func() error) (bool, error) {
+	return true, remove()
+}
+
+func TestCollectGarbageKeepsForeignFiles(t *testing.T) {
+	const hash = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	blobs := newMemBlobs()
+	ours := []string{
+		hash + ".png",
+		hash + "_thumb.png",
+		"3d6f8a10-5b2c-4e7d-9f1a-2c4b6d8e0f13.jpg",
+		"3d6f8a10-5b2c-4e7d-9f1a-2c4b6d8e0f13_medium.jpeg",
+	}
+	foreign := []string{
+		".keep",
+		"notes.txt",
+		"backup/" + hash + ".png",
+		"3d6f8a10-5b2c-4e7d-9f1a-2c4b6d8e0f13.gif",
+		hash + "_large.png",
+		"logo.png",
+	}
+	for _, key := range append(slices.Clone(ours), foreign...) {
+		blobs.Put(key, []byte("x"), "")
+	}
+
+	uc := NewImgUsecase(&gcRepo{}, &recordingPublisher{}, blobs, Limits{}, Signing{})
+	report, err := uc.CollectGarbage(0, false)
+	if err != nil {
+		t.Fatalf("CollectGarbage() error = %v", err)
+	}
+
+	removed := slices.Sorted(slices.Values(report.Removed))
+	if want := slices.Sorted(slices.Values(ours)); !slices.Equal(removed, want) {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+	if got, want := blobs.keys(), slices.Sorted(slices.Values(foreign)); !slices.Equal(got, want) {
+		t.Errorf("files left = %v, want %v", got, want)
+	}
+}
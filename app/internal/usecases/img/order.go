@@ -0,0 +1,27 @@
+package img
+
+import (
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+)
+
+// ReorderImages — задаёт порядок изображений объявления, только для владельца.
+// imgIds — полный список изображений объявления, первое становится обложкой.
+func (i *ImgUsecase) ReorderImages(adId, userId string, imgIds []string) ([]entity.AdImage, error) {
+	authorId, err := i.repo.GetAdAuthorId(adId)
+	if err != nil {
+		return nil, err
+	}
+	if authorId != userId {
+		return nil, apperr.ErrForbidden
+	}
+
+	if err := i.repo.Reorder(adId, imgIds); err != nil {
+		return nil, err
+	}
+	images, err := i.repo.GetImages(adId)
+	if err != nil {
+		return nil, err
+	}
+	return i.view(images, userId), nil
+}
@@ -0,0 +1,171 @@
+package img
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"market/app/internal/storage"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// privacyTTL — сколько помнить, закрыт ли файл; столько же после смены
+// настройки файл может отдаваться по-старому
+const privacyTTL = time.Minute
+
+// Signing — подписанные ссылки на закрытые изображения: HMAC-SHA256 от имени
+// файла и срока действия
+type Signing struct {
+	Secret []byte
+	TTL    time.Duration
+}
+
+// SetImagesPrivate — открывает или закрывает изображения объявления, только для владельца
+func (i *ImgUsecase) SetImagesPrivate(adId, userId string, private bool) ([]entity.AdImage, error) {
+	authorId, err := i.repo.GetAdAuthorId(adId)
+	if err != nil {
+		return nil, err
+	}
+	if authorId != userId {
+		return nil, apperr.ErrForbidden
+	}
+	if private && i.publicStorage() {
+		return nil, apperr.ErrPrivateUnavailable
+	}
+
+	if err := i.repo.SetImagesPrivate(adId, private); err != nil {
+		return nil, err
+	}
+	// какие файлы относятся к объявлению, по кэшу не понять — сбрасываем целиком
+	i.private.Clear()
+
+	images, err := i.repo.GetImages(adId)
+	if err != nil {
+		return nil, err
+	}
+	return i.view(images, userId), nil
+}
+
+// CheckPrivacy — проверка при старте: если файлы отдаются прямо из публичного
+// бакета, закрытых объявлений быть не должно — их файлы доступны по прямой ссылке
+// любому, кто её знает
+func (i *ImgUsecase) CheckPrivacy() error {
+	if !i.publicStorage() {
+		return nil
+	}
+	exists, err := i.repo.HasPrivateImages()
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: some ads have private images, unset S3_PUBLIC_URL or open them", apperr.ErrPrivateUnavailable)
+	}
+	return nil
+}
+
+// publicStorage — ссылки хранилища ведут мимо приложения, и подпись там не проверить
+func (i *ImgUsecase) publicStorage() bool {
+	return !strings.HasPrefix(i.blobs.URL(""), storage.PublicPrefix)
+}
+
+// CheckAccess — можно ли отдать файл key по запросу с параметрами exp и sig.
+// Возвращает срок действия подписи; нулевое время — файл открытый.
+func (i *ImgUsecase) CheckAccess(key, exp, sig string) (time.Time, error) {
+	if exp != "" || sig != "" {
+		expires, err := i.verify(key, exp, sig)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return expires, nil
+	}
+
+	private, err := i.isPrivate(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if private {
+		return time.Time{}, apperr.ErrSignatureRequired
+	}
+	return time.Time{}, nil
+}
+
+// view — изображения такими, какими их должен увидеть viewerId: закрытые
+// анонимному пользователю не показываются, остальным — по подписанным ссылкам
+func (i *ImgUsecase) view(images []entity.AdImage, viewerId string) []entity.AdImage {
+	res := make([]entity.AdImage, 0, len(images))
+	for _, img := range images {
+		if img.Private && viewerId == "" {
+			continue
+		}
+		res = append(res, i.signIfPrivate(img))
+	}
+	return res
+}
+
+func (i *ImgUsecase) signIfPrivate(img entity.AdImage) entity.AdImage {
+	if !img.Private {
+		return img
+	}
+	return i.signImage(img)
+}
+
+// signImage — копия с подписанными ссылками; подписи на один запрос одинаковые,
+// поэтому одинаковые файлы (оригинал вместо копии) получают одинаковый URL
+func (i *ImgUsecase) signImage(img entity.AdImage) entity.AdImage {
+	expires := time.Now().Add(i.signing.TTL).Truncate(time.Minute)
+
+	variants := make(map[string]string, len(img.Variants))
+	for name, u := range img.Variants {
+		variants[name] = i.signURL(keyByURL(u), expires)
+	}
+	img.Variants = variants
+	img.ImageURL = i.signURL(keyByURL(img.ImageURL), expires)
+	return img
+}
+
+// signURL — ссылка всегда ведёт в приложение: проверять подпись умеет только оно
+func (i *ImgUsecase) signURL(key string, expires time.Time) string {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	q := url.Values{"exp": {exp}, "sig": {i.signature(key, exp)}}
+	return storage.PublicPrefix + key + "?" + q.Encode()
+}
+
+func (i *ImgUsecase) verify(key, exp, sig string) (time.Time, error) {
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return time.Time{}, apperr.ErrInvalidSignature
+	}
+	expires := time.Unix(unix, 0)
+	if !time.Now().Before(expires) {
+		return time.Time{}, apperr.ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(sig), []byte(i.signature(key, exp))) {
+		return time.Time{}, apperr.ErrInvalidSignature
+	}
+	return expires, nil
+}
+
+func (i *ImgUsecase) signature(key, exp string) string {
+	mac := hmac.New(sha256.New, i.signing.Secret)
+	mac.Write([]byte(key + "\n" + exp))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (i *ImgUsecase) isPrivate(key string) (bool, error) {
+	if private, ok := i.private.Get(key); ok {
+		return private, nil
+	}
+
+	hash, _ := contentHash(key)
+	private, err := i.repo.BlobPrivate(key, hash)
+	if err != nil {
+		return false, err
+	}
+	i.private.Set(key, private)
+	return private, nil
+}
@@ -0,0 +1,143 @@
+package img
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+
+	"golang.org/x/image/draw"
+)
+
+// variantSizes — уменьшенные копии, которые создаются при загрузке;
+// размер — максимальная сторона в пикселях
+var variantSizes = []struct {
+	name string
+	size int
+}{
+	{entity.ImageThumb, 200},
+	{entity.ImageMedium, 800},
+}
+
+const jpegQuality = 90
+
+// maxImagePixels — больше не декодируем: сжатый файл в несколько мегабайт может
+// объявить огромные размеры, а декодированная картинка занимает до 4 байт на пиксель
+const maxImagePixels = 25_000_000
+
+// normalize — декодирует загруженный файл, применяет поворот из EXIF и кодирует
+// заново. Кодировщики стандартной библиотеки не пишут метаданные, поэтому
+// EXIF (в том числе GPS-координаты) и прочие чанки в сохранённый файл не попадают.
+func normalize(data []byte, contentType string) (image.Image, []byte, error) {
+	// размеры читаются из заголовка, до выделения памяти под пиксели
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", apperr.ErrInvalidImage, err)
+	}
+	// по сигнатуре файл выглядел иначе, чем оказался на деле
+	if "image/"+format != contentType {
+		return nil, nil, fmt.Errorf("%w: %s declared as %s", apperr.ErrInvalidImage, format, contentType)
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > maxImagePixels {
+		return nil, nil, fmt.Errorf("%w: %dx%d is larger than %d pixels", apperr.ErrInvalidImage, cfg.Width, cfg.Height, maxImagePixels)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", apperr.ErrInvalidImage, err)
+	}
+
+	img = orient(img, orientation(data, contentType))
+
+	encoded, err := encode(img, contentType)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode image failed: %w", err)
+	}
+	return img, encoded, nil
+}
+
+// scale — уменьшает изображение так, чтобы большая сторона была не больше size.
+// Если оно и так помещается, возвращает nil: увеличивать копии нет смысла.
+func scale(src image.Image, size int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= size && h <= size {
+		return nil
+	}
+
+	if w >= h {
+		h = max(1, h*size/w)
+		w = size
+	} else {
+		w = max(1, w*size/h)
+		h = size
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+	return dst
+}
+
+// encode — в тот же формат, что и оригинал
+func encode(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch contentType {
+	case "image/png":
+		err = png.Encode(&buf, img)
+	default:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// blobFile — файл, который нужно записать в хранилище
+type blobFile struct {
+	key  string
+	data []byte
+}
+
+// renderVariants — готовит оригинал и уменьшенные копии под именами
+// <base><ext>, <base>_thumb<ext>, ...; возвращает файлы, URL по названию размера
+// и сколько байт они займут. Сами файлы пишет storeFiles.
+func (i *ImgUsecase) renderVariants(base, ext, contentType string, data []byte, src image.Image) ([]blobFile, map[string]string, int64, error) {
+	files := []blobFile{{key: base + ext, data: data}}
+	originalURL := i.blobs.URL(base + ext)
+	variants := map[string]string{entity.ImageOriginal: originalURL}
+	size := int64(len(data))
+
+	for _, v := range variantSizes {
+		scaled := scale(src, v.size)
+		if scaled == nil {
+			variants[v.name] = originalURL
+			continue
+		}
+
+		encoded, err := encode(scaled, contentType)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("encode %s variant failed: %w", v.name, err)
+		}
+		key := base + "_" + v.name + ext
+		files = append(files, blobFile{key: key, data: encoded})
+		variants[v.name] = i.blobs.URL(key)
+		size += int64(len(encoded))
+	}
+	return files, variants, size, nil
+}
+
+// storeFiles — записывает файлы; если что-то не удалось, уже записанные удаляются
+func (i *ImgUsecase) storeFiles(files []blobFile, contentType string) error {
+	for n, f := range files {
+		if err := i.blobs.Put(f.key, f.data, contentType); err != nil {
+			i.removeFiles(files[:n])
+			return fmt.Errorf("save image file failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package img
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"market/app/internal/apperr"
+	"slices"
+	"testing"
+)
+
+// hugePNG — маленький PNG, в заголовке которого объявлены размеры w×h
+func hugePNG(t *testing.T, w, h uint32) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	// сигнатура (8), длина и тип IHDR (8), затем ширина и высота; CRC — после 13 байт данных
+	binary.BigEndian.PutUint32(data[16:], w)
+	binary.BigEndian.PutUint32(data[20:], h)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestNormalizeRejectsHugeImage(t *testing.T) {
+	_, _, err := normalize(hugePNG(t, 100_000, 100_000), "image/png")
+	if !errors.Is(err, apperr.ErrInvalidImage) {
+		t.Fatalf("normalize() error = %v, want %v", err, apperr.ErrInvalidImage)
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// 3×2: верхняя строка a b c, нижняя d e f
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	for i := 0; i < 6; i++ {
+		src.Set(i%3, i/3, color.RGBA{R: uint8('a' + i), A: 255})
+	}
+
+	tests := []struct {
+		orientation int
+		want        []string // строки результата сверху вниз
+	}{
+		{1, []string{"abc", "def"}},
+		{2, []string{"cba", "fed"}},
+		{3, []string{"fed", "cba"}},
+		{4, []string{"def", "abc"}},
+		{5, []string{"ad", "be", "cf"}},
+		{6, []string{"da", "eb", "fc"}},
+		{7, []string{"fc", "eb", "da"}},
+		{8, []string{"cf", "be", "ad"}},
+	}
+
+	for _, tt := range tests {
+		dst := orient(src, tt.orientation)
+		b := dst.Bounds()
+		got := make([]string, 0, b.Dy())
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			var row []byte
+			for x := b.Min.X; x < b.Max.X; x++ {
+				r, _, _, _ := dst.At(x, y).RGBA()
+				row = append(row, byte(r>>8))
+			}
+			got = append(got, string(row))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("orientation %d: got %v, want %v", tt.orientation, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package offers
+
+import (
+	"market/app/internal/entity"
+	"time"
+)
+
+type OffersRepo interface {
+	Create(offer entity.Offer) (entity.Offer, error)
+	GetById(id string) (entity.Offer, error)
+	GetByAd(adId, buyerId string, now time.Time) ([]entity.Offer, error)
+	ExpireOpen(adId string, now time.Time) error
+	UpdateStatus(offer entity.Offer, from entity.OfferStatus) (entity.Offer, error)
+	Accept(offer entity.Offer, from entity.OfferStatus) (entity.Offer, error)
+	GetAdAuthorId(adId string) (string, error)
+}
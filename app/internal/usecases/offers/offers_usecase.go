@@ -0,0 +1,222 @@
+package offers
+
+import (
+	"fmt"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"market/app/internal/utils"
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	offerTTL         = 72 * time.Hour
+	maxMessageLength = 500
+)
+
+type party int
+
+const (
+	seller party = iota
+	buyer
+)
+
+// transition — чей сейчас ход и в какие статусы он может перевести предложение
+type transition struct {
+	turn party
+	to   []entity.OfferStatus
+}
+
+// transitions — конечный автомат предложений. Статусы, которых здесь нет, финальные.
+var transitions = map[entity.OfferStatus]transition{
+	entity.OfferPending: {
+		turn: seller,
+		to:   []entity.OfferStatus{entity.OfferAccepted, entity.OfferRejected, entity.OfferCountered},
+	},
+	entity.OfferCountered: {
+		turn: buyer,
+		to:   []entity.OfferStatus{entity.OfferAccepted, entity.OfferRejected},
+	},
+}
+
+type Offers struct {
+	repo OffersRepo
+}
+
+func NewOffers(repo OffersRepo) *Offers {
+	return &Offers{repo}
+}
+
+func (o *Offers) Create(offer entity.Offer) (entity.Offer, error) {
+	if err := o.validate(offer.Amount, offer.Message); err != nil {
+		return entity.Offer{}, err
+	}
+
+	authorId, err := o.repo.GetAdAuthorId(offer.AdId)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("get ad author failed: %w", err)
+	}
+	if authorId == offer.BuyerId {
+		return entity.Offer{}, apperr.ErrOfferOnOwnAd
+	}
+
+	id, err := utils.GenerateUUID()
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("uuid generation error: %w", err)
+	}
+
+	now := time.Now().UTC()
+	offer.Id = id
+	offer.Status = entity.OfferPending
+	offer.CreatedAt = now
+	offer.UpdatedAt = now
+	offer.ExpiresAt = now.Add(offerTTL)
+
+	saved, err := o.repo.Create(offer)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("offer creation failed: %w", err)
+	}
+	return saved, nil
+}
+
+// GetByAd — владелец объявления видит все предложения, покупатель — только свои
+func (o *Offers) GetByAd(adId, userId string) ([]entity.Offer, error) {
+	authorId, err := o.repo.GetAdAuthorId(adId)
+	if err != nil {
+		return nil, fmt.Errorf("get ad author failed: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if err := o.repo.ExpireOpen(adId, now); err != nil {
+		return nil, fmt.Errorf("expire offers failed: %w", err)
+	}
+
+	buyerId := userId
+	if authorId == userId {
+		buyerId = ""
+	}
+
+	offers, err := o.repo.GetByAd(adId, buyerId, now)
+	if err != nil {
+		return nil, fmt.Errorf("get offers failed: %w", err)
+	}
+	return offers, nil
+}
+
+// Accept — принимает предложение. hideAd учитывается, только если принимает продавец;
+// покупатель принимает встречное предложение с тем hideAd, что указал продавец.
+func (o *Offers) Accept(offerId, userId string, hideAd bool) (entity.Offer, error) {
+	offer, authorId, err := o.prepare(offerId, userId, entity.OfferAccepted)
+	if err != nil {
+		return entity.Offer{}, err
+	}
+
+	from := offer.Status
+	if userId == authorId {
+		offer.HideAd = hideAd
+	}
+	offer.UpdatedAt = time.Now().UTC()
+
+	accepted, err := o.repo.Accept(offer, from)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("accept offer failed: %w", err)
+	}
+	return accepted, nil
+}
+
+func (o *Offers) Reject(offerId, userId string) (entity.Offer, error) {
+	offer, _, err := o.prepare(offerId, userId, entity.OfferRejected)
+	if err != nil {
+		return entity.Offer{}, err
+	}
+
+	from := offer.Status
+	offer.Status = entity.OfferRejected
+	offer.UpdatedAt = time.Now().UTC()
+
+	rejected, err := o.repo.UpdateStatus(offer, from)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("reject offer failed: %w", err)
+	}
+	return rejected, nil
+}
+
+// Counter — встречное предложение продавца, срок действия отсчитывается заново
+func (o *Offers) Counter(offerId, userId string, amount float64, message string, hideAd bool) (entity.Offer, error) {
+	if err := o.validate(amount, message); err != nil {
+		return entity.Offer{}, err
+	}
+
+	offer, _, err := o.prepare(offerId, userId, entity.OfferCountered)
+	if err != nil {
+		return entity.Offer{}, err
+	}
+
+	from := offer.Status
+	now := time.Now().UTC()
+	offer.Status = entity.OfferCountered
+	offer.CounterAmount = amount
+	offer.CounterMessage = message
+	offer.HideAd = hideAd
+	offer.UpdatedAt = now
+	offer.ExpiresAt = now.Add(offerTTL)
+
+	countered, err := o.repo.UpdateStatus(offer, from)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("counter offer failed: %w", err)
+	}
+	return countered, nil
+}
+
+// prepare — загружает предложение и проверяет, что userId может перевести его в статус to
+func (o *Offers) prepare(offerId, userId string, to entity.OfferStatus) (entity.Offer, string, error) {
+	offer, err := o.repo.GetById(offerId)
+	if err != nil {
+		return entity.Offer{}, "", fmt.Errorf("get offer failed: %w", err)
+	}
+
+	authorId, err := o.repo.GetAdAuthorId(offer.AdId)
+	if err != nil {
+		return entity.Offer{}, "", fmt.Errorf("get ad author failed: %w", err)
+	}
+	if userId != authorId && userId != offer.BuyerId {
+		return entity.Offer{}, "", apperr.ErrForbidden
+	}
+
+	now := time.Now().UTC()
+	if offer.IsOpen() && !offer.ExpiresAt.After(now) {
+		from := offer.Status
+		offer.Status = entity.OfferExpired
+		offer.UpdatedAt = now
+		if _, err := o.repo.UpdateStatus(offer, from); err != nil {
+			return entity.Offer{}, "", fmt.Errorf("expire offer failed: %w", err)
+		}
+		return entity.Offer{}, "", apperr.ErrOfferExpired
+	}
+
+	rule, ok := transitions[offer.Status]
+	if !ok || !slices.Contains(rule.to, to) {
+		return entity.Offer{}, "", apperr.ErrOfferTransition
+	}
+
+	turn := offer.BuyerId
+	if rule.turn == seller {
+		turn = authorId
+	}
+	if userId != turn {
+		return entity.Offer{}, "", apperr.ErrForbidden
+	}
+
+	return offer, authorId, nil
+}
+
+func (o *Offers) validate(amount float64, message string) error {
+	if amount <= 0 {
+		return apperr.ErrInvalidOfferAmount
+	}
+	if utf8.RuneCountInString(message) > maxMessageLength {
+		return apperr.ErrOfferMessageTooLong
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package orders
+
+import "market/app/internal/entity"
+
+type OrdersRepo interface {
+	Create(order entity.Order, actorId string) (entity.Order, error)
+	Transition(order entity.Order, from entity.OrderStatus, actorId, note string, apply func() error) (entity.Order, error)
+	GetById(id string) (entity.Order, error)
+	GetByUser(userId string) ([]entity.Order, error)
+	GetEvents(orderId string) ([]entity.OrderEvent, error)
+	GetAdForOrder(adId, buyerId string) (string, float64, error)
+}
+
+// PaymentProvider — платёжный шлюз: деньги сначала блокируются (Authorize),
+// а списываются (Capture) или возвращаются (Void) позже
+type PaymentProvider interface {
+	Authorize(orderId string, amount float64) (string, error)
+	Capture(paymentId string) error
+	Void(paymentId string) error
+}
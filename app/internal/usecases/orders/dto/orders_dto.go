@@ -0,0 +1,8 @@
+package dto
+
+import "market/app/internal/entity"
+
+type OrderDetailed struct {
+	Order  entity.Order
+	Events []entity.OrderEvent
+}
@@ -0,0 +1,224 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"market/app/internal/usecases/orders/dto"
+	"market/app/internal/utils"
+	"slices"
+	"time"
+)
+
+type party int
+
+const (
+	sellerParty party = iota
+	buyerParty
+	anyParty
+)
+
+// rule — из каких статусов и кем заказ может быть переведён в целевой статус
+type rule struct {
+	from []entity.OrderStatus
+	by   party
+}
+
+// transitions — ручные переходы заказа. created → authorized/failed выполняет
+// сам сервис по ответу платёжного провайдера.
+var transitions = map[entity.OrderStatus]rule{
+	entity.OrderConfirmed: {
+		from: []entity.OrderStatus{entity.OrderAuthorized},
+		by:   sellerParty,
+	},
+	entity.OrderCompleted: {
+		from: []entity.OrderStatus{entity.OrderConfirmed},
+		by:   buyerParty,
+	},
+	entity.OrderCancelled: {
+		from: []entity.OrderStatus{entity.OrderCreated, entity.OrderAuthorized, entity.OrderConfirmed},
+		by:   anyParty,
+	},
+}
+
+type Orders struct {
+	repo     OrdersRepo
+	payments PaymentProvider
+}
+
+func NewOrders(repo OrdersRepo, payments PaymentProvider) *Orders {
+	return &Orders{repo, payments}
+}
+
+// Create — создаёт заказ и сразу блокирует деньги покупателя у платёжного провайдера
+func (o *Orders) Create(adId, buyerId string) (entity.Order, error) {
+	sellerId, amount, err := o.repo.GetAdForOrder(adId, buyerId)
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("get ad failed: %w", err)
+	}
+	if sellerId == buyerId {
+		return entity.Order{}, apperr.ErrOrderOnOwnAd
+	}
+
+	id, err := utils.GenerateUUID()
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("uuid generation error: %w", err)
+	}
+
+	now := time.Now().UTC()
+	order, err := o.repo.Create(entity.Order{
+		Id:        id,
+		AdId:      adId,
+		BuyerId:   buyerId,
+		SellerId:  sellerId,
+		Amount:    amount,
+		Status:    entity.OrderCreated,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, buyerId)
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("order creation failed: %w", err)
+	}
+
+	paymentId, payErr := o.payments.Authorize(order.Id, order.Amount)
+	if payErr != nil {
+		order.Status = entity.OrderFailed
+		order.UpdatedAt = time.Now().UTC()
+		if _, err := o.repo.Transition(order, entity.OrderCreated, "", payErr.Error(), nil); err != nil {
+			return entity.Order{}, fmt.Errorf("order transition failed: %w", err)
+		}
+		return entity.Order{}, fmt.Errorf("%w: %v", apperr.ErrPaymentFailed, payErr)
+	}
+
+	order.Status = entity.OrderAuthorized
+	order.PaymentId = paymentId
+	order.UpdatedAt = time.Now().UTC()
+	authorized, err := o.repo.Transition(order, entity.OrderCreated, "", "payment authorized", nil)
+	if err != nil {
+		// без сохранённого payment id отмена заказа деньги не вернёт — снимаем блокировку сразу
+		if voidErr := o.payments.Void(paymentId); voidErr != nil {
+			err = errors.Join(err, fmt.Errorf("%w: %v", apperr.ErrPaymentFailed, voidErr))
+		}
+		return entity.Order{}, fmt.Errorf("order transition failed: %w", err)
+	}
+	return authorized, nil
+}
+
+func (o *Orders) GetById(orderId, userId string) (dto.OrderDetailed, error) {
+	order, err := o.repo.GetById(orderId)
+	if err != nil {
+		return dto.OrderDetailed{}, fmt.Errorf("get order failed: %w", err)
+	}
+	if userId != order.BuyerId && userId != order.SellerId {
+		return dto.OrderDetailed{}, apperr.ErrForbidden
+	}
+
+	events, err := o.repo.GetEvents(order.Id)
+	if err != nil {
+		return dto.OrderDetailed{}, fmt.Errorf("get order events failed: %w", err)
+	}
+
+	return dto.OrderDetailed{
+		Order:  order,
+		Events: events,
+	}, nil
+}
+
+func (o *Orders) GetByUser(userId string) ([]entity.Order, error) {
+	orders, err := o.repo.GetByUser(userId)
+	if err != nil {
+		return nil, fmt.Errorf("get orders failed: %w", err)
+	}
+	return orders, nil
+}
+
+// Confirm — продавец подтверждает, что готов передать товар
+func (o *Orders) Confirm(orderId, userId string) (entity.Order, error) {
+	order, err := o.prepare(orderId, userId, entity.OrderConfirmed)
+	if err != nil {
+		return entity.Order{}, err
+	}
+	return o.transition(order, entity.OrderConfirmed, userId, "confirmed by seller", nil)
+}
+
+// Receive — покупатель подтверждает получение, деньги списываются
+func (o *Orders) Receive(orderId, userId string) (entity.Order, error) {
+	order, err := o.prepare(orderId, userId, entity.OrderCompleted)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	capture := func() error {
+		if err := o.payments.Capture(order.PaymentId); err != nil {
+			return fmt.Errorf("%w: %v", apperr.ErrPaymentFailed, err)
+		}
+		return nil
+	}
+	return o.transition(order, entity.OrderCompleted, userId, "received by buyer, payment captured", capture)
+}
+
+// Cancel — отмена до завершения сделки, заблокированные деньги возвращаются
+func (o *Orders) Cancel(orderId, userId string) (entity.Order, error) {
+	order, err := o.prepare(orderId, userId, entity.OrderCancelled)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	var void func() error
+	if order.PaymentId != "" {
+		void = func() error {
+			if err := o.payments.Void(order.PaymentId); err != nil {
+				return fmt.Errorf("%w: %v", apperr.ErrPaymentFailed, err)
+			}
+			return nil
+		}
+	}
+
+	note := "cancelled by buyer"
+	if userId == order.SellerId {
+		note = "cancelled by seller"
+	}
+	return o.transition(order, entity.OrderCancelled, userId, note, void)
+}
+
+func (o *Orders) prepare(orderId, userId string, to entity.OrderStatus) (entity.Order, error) {
+	order, err := o.repo.GetById(orderId)
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("get order failed: %w", err)
+	}
+	if userId != order.BuyerId && userId != order.SellerId {
+		return entity.Order{}, apperr.ErrForbidden
+	}
+
+	r := transitions[to]
+	if !slices.Contains(r.from, order.Status) {
+		return entity.Order{}, apperr.ErrOrderTransition
+	}
+
+	switch r.by {
+	case sellerParty:
+		if userId != order.SellerId {
+			return entity.Order{}, apperr.ErrForbidden
+		}
+	case buyerParty:
+		if userId != order.BuyerId {
+			return entity.Order{}, apperr.ErrForbidden
+		}
+	}
+	return order, nil
+}
+
+// transition — сохраняет переход; pay вызывается под блокировкой заказа, только если
+// заказ всё ещё в прежнем статусе, и при ошибке переход не сохраняется
+func (o *Orders) transition(order entity.Order, to entity.OrderStatus, actorId, note string, pay func() error) (entity.Order, error) {
+	from := order.Status
+	order.Status = to
+	order.UpdatedAt = time.Now().UTC()
+
+	res, err := o.repo.Transition(order, from, actorId, note, pay)
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("order transition failed: %w", err)
+	}
+	return res, nil
+}
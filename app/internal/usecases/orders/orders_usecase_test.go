@@ -0,0 +1,274 @@
+package orders
+
+import (
+	"errors"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+	"market/app/internal/payment/fake"
+	"testing"
+)
+
+const (
+	testAdId     = "7c0d2d4e-4a57-4c43-9d0e-6a4c2f3b8e11"
+	testSeller   = "1f9a7b52-3c1e-4b8d-a0f4-5e2d9c6b7a30"
+	testBuyer    = "9b3e5c71-2d4f-4a6e-8c1b-0f7e6d5a4b92"
+	testStranger = "4e8d2c1a-6b7f-4a3e-9d5c-2f1e0b9a8c76"
+)
+
+// fakeRepo — заказы в памяти; Transition ведёт себя как настоящий: переход
+// сохраняется, только если заказ в статусе from и apply прошёл
+type fakeRepo struct {
+	price  float64
+	orders map[string]entity.Order
+	events []entity.OrderEvent
+	// failTransition — ошибка следующего перехода в этот статус
+	failTransition map[entity.OrderStatus]error
+}
+
+func newFakeRepo(price float64) *fakeRepo {
+	return &fakeRepo{
+		price:          price,
+		orders:         make(map[string]entity.Order),
+		failTransition: make(map[entity.OrderStatus]error),
+	}
+}
+
+func (r *fakeRepo) Create(order entity.Order, actorId string) (entity.Order, error) {
+	r.orders[order.Id] = order
+	r.events = append(r.events, entity.OrderEvent{OrderId: order.Id, ToStatus: order.Status, ActorId: actorId})
+	return order, nil
+}
+
+func (r *fakeRepo) Transition(order entity.Order, from entity.OrderStatus, actorId, note string, apply func() error) (entity.Order, error) {
+	if err, ok := r.failTransition[order.Status]; ok {
+		delete(r.failTransition, order.Status)
+		return entity.Order{}, err
+	}
+	stored, ok := r.orders[order.Id]
+	if !ok || stored.Status != from {
+		return entity.Order{}, apperr.ErrOrderTransition
+	}
+	if apply != nil {
+		if err := apply(); err != nil {
+			return entity.Order{}, err
+		}
+	}
+	r.orders[order.Id] = order
+	r.events = append(r.events, entity.OrderEvent{OrderId: order.Id, FromStatus: from, ToStatus: order.Status, ActorId: actorId, Note: note})
+	return order, nil
+}
+
+func (r *fakeRepo) GetById(id string) (entity.Order, error) {
+	order, ok := r.orders[id]
+	if !ok {
+		return entity.Order{}, apperr.ErrOrderNotFound
+	}
+	return order, nil
+}
+
+func (r *fakeRepo) GetByUser(userId string) ([]entity.Order, error) {
+	panic("not used")
+}
+
+func (r *fakeRepo) GetEvents(orderId string) ([]entity.OrderEvent, error) {
+	panic("not used")
+}
+
+func (r *fakeRepo) GetAdForOrder(adId, buyerId string) (string, float64, error) {
+	if adId != testAdId {
+		return "", 0, apperr.ErrAdsNotFound
+	}
+	return testSeller, r.price, nil
+}
+
+// recordingProvider — fake.Provider, который запоминает выданные payment id
+type recordingProvider struct {
+	*fake.Provider
+	ids []string
+}
+
+func (p *recordingProvider) Authorize(orderId string, amount float64) (string, error) {
+	id, err := p.Provider.Authorize(orderId, amount)
+	if err == nil {
+		p.ids = append(p.ids, id)
+	}
+	return id, err
+}
+
+func newTestOrders(price float64) (*Orders, *fakeRepo, *recordingProvider) {
+	repo := newFakeRepo(price)
+	payments := &recordingProvider{Provider: fake.NewProvider(1000)}
+	return NewOrders(repo, payments), repo, payments
+}
+
+// paymentState — состояние платежа по ответам провайдера: повторный Capture или
+// Void того же исхода проходит, противоположный — нет
+func paymentState(t *testing.T, p *fake.Provider, paymentId string) string {
+	t.Helper()
+	switch {
+	case p.Capture(paymentId) == nil && p.Void(paymentId) != nil:
+		return "captured"
+	case p.Void(paymentId) == nil && p.Capture(paymentId) != nil:
+		return "voided"
+	}
+	return "unknown"
+}
+
+func TestCreateAuthorizesPayment(t *testing.T) {
+	uc, repo, payments := newTestOrders(500)
+
+	order, err := uc.Create(testAdId, testBuyer)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if order.Status != entity.OrderAuthorized || order.PaymentId == "" {
+		t.Fatalf("order = %+v, want authorized with a payment id", order)
+	}
+	if len(payments.ids) != 1 || payments.ids[0] != order.PaymentId {
+		t.Errorf("payment id = %q, want %v", order.PaymentId, payments.ids)
+	}
+	if stored := repo.orders[order.Id]; stored.Status != entity.OrderAuthorized || stored.PaymentId != order.PaymentId {
+		t.Errorf("stored order = %+v, want it authorized with the payment id", stored)
+	}
+}
+
+func TestCreateFailsWhenPaymentDeclined(t *testing.T) {
+	uc, repo, payments := newTestOrders(5000)
+
+	_, err := uc.Create(testAdId, testBuyer)
+	if !errors.Is(err, apperr.ErrPaymentFailed) {
+		t.Fatalf("Create() error = %v, want %v", err, apperr.ErrPaymentFailed)
+	}
+	if len(repo.orders) != 1 {
+		t.Fatalf("orders = %d, want 1", len(repo.orders))
+	}
+	for _, order := range repo.orders {
+		if order.Status != entity.OrderFailed {
+			t.Errorf("order status = %q, want %q", order.Status, entity.OrderFailed)
+		}
+	}
+	if len(payments.ids) != 0 {
+		t.Errorf("declined payment left ids %v", payments.ids)
+	}
+}
+
+func TestCreateVoidsPaymentWhenAuthorizeNotSaved(t *testing.T) {
+	uc, repo, payments := newTestOrders(500)
+	repo.failTransition[entity.OrderAuthorized] = apperr.ErrOrderTransition
+
+	if _, err := uc.Create(testAdId, testBuyer); !errors.Is(err, apperr.ErrOrderTransition) {
+		t.Fatalf("Create() error = %v, want %v", err, apperr.ErrOrderTransition)
+	}
+	if len(payments.ids) != 1 {
+		t.Fatalf("payment ids = %v, want one", payments.ids)
+	}
+	if got := paymentState(t, payments.Provider, payments.ids[0]); got != "voided" {
+		t.Errorf("payment is %s, want voided", got)
+	}
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	uc, _, payments := newTestOrders(500)
+
+	order, err := uc.Create(testAdId, testBuyer)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// покупатель не может подтвердить за продавца, получить неподтверждённый заказ нельзя
+	if _, err := uc.Confirm(order.Id, testBuyer); !errors.Is(err, apperr.ErrForbidden) {
+		t.Errorf("Confirm() by buyer error = %v, want %v", err, apperr.ErrForbidden)
+	}
+	if _, err := uc.Receive(order.Id, testBuyer); !errors.Is(err, apperr.ErrOrderTransition) {
+		t.Errorf("Receive() before confirm error = %v, want %v", err, apperr.ErrOrderTransition)
+	}
+
+	order, err = uc.Confirm(order.Id, testSeller)
+	if err != nil {
+		t.Fatalf("Confirm() error = %v", err)
+	}
+	if order.Status != entity.OrderConfirmed {
+		t.Fatalf("status = %q, want %q", order.Status, entity.OrderConfirmed)
+	}
+
+	if _, err := uc.Receive(order.Id, testSeller); !errors.Is(err, apperr.ErrForbidden) {
+		t.Errorf("Receive() by seller error = %v, want %v", err, apperr.ErrForbidden)
+	}
+	if _, err := uc.Receive(order.Id, testStranger); !errors.Is(err, apperr.ErrForbidden) {
+		t.Errorf("Receive() by stranger error = %v, want %v", err, apperr.ErrForbidden)
+	}
+
+	order, err = uc.Receive(order.Id, testBuyer)
+	if err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+	if order.Status != entity.OrderCompleted {
+		t.Fatalf("status = %q, want %q", order.Status, entity.OrderCompleted)
+	}
+	if got := paymentState(t, payments.Provider, order.PaymentId); got != "captured" {
+		t.Errorf("payment is %s, want captured", got)
+	}
+
+	// завершённый заказ не отменить
+	if _, err := uc.Cancel(order.Id, testBuyer); !errors.Is(err, apperr.ErrOrderTransition) {
+		t.Errorf("Cancel() completed order error = %v, want %v", err, apperr.ErrOrderTransition)
+	}
+}
+
+func TestCancelVoidsPayment(t *testing.T) {
+	for _, actor := range []string{testBuyer, testSeller} {
+		uc, repo, payments := newTestOrders(500)
+
+		order, err := uc.Create(testAdId, testBuyer)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if _, err := uc.Cancel(order.Id, testStranger); !errors.Is(err, apperr.ErrForbidden) {
+			t.Errorf("Cancel() by stranger error = %v, want %v", err, apperr.ErrForbidden)
+		}
+
+		order, err = uc.Cancel(order.Id, actor)
+		if err != nil {
+			t.Fatalf("Cancel() by %s error = %v", actor, err)
+		}
+		if order.Status != entity.OrderCancelled {
+			t.Fatalf("status = %q, want %q", order.Status, entity.OrderCancelled)
+		}
+		if got := paymentState(t, payments.Provider, order.PaymentId); got != "voided" {
+			t.Errorf("payment is %s, want voided", got)
+		}
+
+		if _, err := uc.Confirm(order.Id, testSeller); !errors.Is(err, apperr.ErrOrderTransition) {
+			t.Errorf("Confirm() cancelled order error = %v, want %v", err, apperr.ErrOrderTransition)
+		}
+		if last := repo.events[len(repo.events)-1]; last.ToStatus != entity.OrderCancelled || last.ActorId != actor {
+			t.Errorf("last event = %+v, want cancel by %s", last, actor)
+		}
+	}
+}
+
+func TestFailedPaymentKeepsOrderStatus(t *testing.T) {
+	uc, repo, payments := newTestOrders(500)
+
+	order, err := uc.Create(testAdId, testBuyer)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	// блокировка уже снята на стороне провайдера — списание не пройдёт, статус не меняется
+	if err := payments.Void(order.PaymentId); err != nil {
+		t.Fatal(err)
+	}
+	if err := payments.Capture(order.PaymentId); err == nil {
+		t.Fatal("capture after void succeeded")
+	}
+
+	if _, err := uc.Confirm(order.Id, testSeller); err != nil {
+		t.Fatalf("Confirm() error = %v", err)
+	}
+	if _, err := uc.Receive(order.Id, testBuyer); !errors.Is(err, apperr.ErrPaymentFailed) {
+		t.Fatalf("Receive() error = %v, want %v", err, apperr.ErrPaymentFailed)
+	}
+	if got := repo.orders[order.Id].Status; got != entity.OrderConfirmed {
+		t.Errorf("status after failed capture = %q, want %q", got, entity.OrderConfirmed)
+	}
+}
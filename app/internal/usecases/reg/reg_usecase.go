@@ -28,6 +28,12 @@ func (r *RegistryUsecase) Registration(user entity.User) (entity.User, error) {
 		return entity.User{}, fmt.Errorf("password validation failed: %w", err)
 	}
 
+	phone, ok := entity.NormalizePhone(user.Phone)
+	if !ok {
+		return entity.User{}, fmt.Errorf("phone validation failed: %w", apperr.ErrInvalidPhone)
+	}
+	user.Phone = phone
+
 	exists, err := r.repo.EmailExists(user.Email)
 	if err != nil {
 		return entity.User{}, fmt.Errorf("email existence check failed: %w", err)
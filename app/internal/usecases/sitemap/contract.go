@@ -0,0 +1,15 @@
+package sitemap
+
+import (
+	"market/app/internal/entity"
+	"market/app/internal/events"
+)
+
+type SitemapRepo interface {
+	Pages(pageSize int) ([]entity.SitemapPage, error)
+	Page(number, pageSize int) ([]entity.SitemapURL, error)
+}
+
+type Subscriber interface {
+	Subscribe(userId string) *events.Subscription
+}
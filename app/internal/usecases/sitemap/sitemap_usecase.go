@@ -0,0 +1,111 @@
+package sitemap
+
+import (
+	"context"
+	"fmt"
+	"market/app/internal/apperr"
+	"market/app/internal/cache"
+	"market/app/internal/entity"
+	"market/app/internal/events"
+	"time"
+)
+
+const (
+	// PageSize — ограничение протокола sitemaps: не больше 50 000 адресов в одном файле
+	PageSize = 50000
+	// cacheTTL — страховка на случай пропущенных событий, обычно кэш сбрасывается по ним
+	cacheTTL = time.Hour
+)
+
+// Sitemap — кэшированный sitemap публичных объявлений. Кэш сбрасывается по событиям
+// создания и удаления объявлений (Ads.Create, Ads.Delete, планировщик публикаций)
+// и добавления изображений, страницы пересобираются при следующем запросе.
+type Sitemap struct {
+	repo  SitemapRepo
+	index *cache.TTL[int, []entity.SitemapPage]
+	pages *cache.TTL[int, []entity.SitemapURL]
+}
+
+func NewSitemap(repo SitemapRepo) *Sitemap {
+	return &Sitemap{
+		repo:  repo,
+		index: cache.NewTTL[int, []entity.SitemapPage](cacheTTL),
+		pages: cache.NewTTL[int, []entity.SitemapURL](cacheTTL),
+	}
+}
+
+// Index — список страниц sitemap. Если объявлений нет, возвращается одна пустая страница,
+// чтобы индекс оставался валидным.
+func (s *Sitemap) Index() ([]entity.SitemapPage, error) {
+	if pages, ok := s.index.Get(0); ok {
+		return pages, nil
+	}
+
+	pages, err := s.repo.Pages(PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("get sitemap pages failed: %w", err)
+	}
+	if len(pages) == 0 {
+		pages = []entity.SitemapPage{{Number: 1}}
+	}
+
+	s.index.Set(0, pages)
+	return pages, nil
+}
+
+func (s *Sitemap) Page(number int) ([]entity.SitemapURL, error) {
+	if number < 1 {
+		return nil, apperr.ErrSitemapPageNotFound
+	}
+	if urls, ok := s.pages.Get(number); ok {
+		return urls, nil
+	}
+
+	urls, err := s.repo.Page(number, PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("get sitemap page failed: %w", err)
+	}
+	if len(urls) == 0 && number > 1 {
+		return nil, apperr.ErrSitemapPageNotFound
+	}
+
+	s.pages.Set(number, urls)
+	return urls, nil
+}
+
+func (s *Sitemap) Invalidate() {
+	s.index.Clear()
+	s.pages.Clear()
+}
+
+// Watch — сбрасывает кэш на каждое событие хаба, пока не отменён ctx.
+// Если хаб отключил подписку, кэш сбрасывается и подписка создаётся заново:
+// пропущенные события могли изменить список объявлений.
+func (s *Sitemap) Watch(ctx context.Context, hub Subscriber) {
+	for {
+		sub := hub.Subscribe("")
+		s.listen(ctx, sub)
+		sub.Close()
+		s.Invalidate()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (s *Sitemap) listen(ctx context.Context, sub *events.Subscription) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			s.Invalidate()
+		}
+	}
+}
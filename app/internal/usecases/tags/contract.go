@@ -0,0 +1,7 @@
+package tags
+
+import "market/app/internal/entity"
+
+type TagsRepo interface {
+	Suggest(prefix string, limit int) ([]entity.Tag, error)
+}
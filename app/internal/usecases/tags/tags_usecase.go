@@ -0,0 +1,41 @@
+package tags
+
+import (
+	"fmt"
+	"market/app/internal/apperr"
+	"market/app/internal/entity"
+)
+
+const (
+	suggestDefaultLimit = 10
+	suggestMaxLimit     = 50
+)
+
+type Tags struct {
+	repo TagsRepo
+}
+
+func NewTags(repo TagsRepo) *Tags {
+	return &Tags{repo}
+}
+
+// Suggest — автодополнение тегов по префиксу, популярные выше
+func (t *Tags) Suggest(prefix string, limit int) ([]entity.Tag, error) {
+	if limit < 0 || limit > suggestMaxLimit {
+		return nil, apperr.NewFieldError("limit", fmt.Sprintf("must be between 1 and %d", suggestMaxLimit))
+	}
+	if limit == 0 {
+		limit = suggestDefaultLimit
+	}
+
+	prefix = entity.NormalizeTag(prefix)
+	if entity.TagTooLong(prefix) {
+		return nil, apperr.NewFieldError("prefix", fmt.Sprintf("must be at most %d characters", entity.MaxTagLength))
+	}
+
+	tags, err := t.repo.Suggest(prefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("suggest tags failed: %w", err)
+	}
+	return tags, nil
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"net/http"
+	"strings"
+)
+
+// BaseURL — схема и хост, по которым пришёл запрос, для абсолютных ссылок в ответах.
+// За прокси схема берётся из X-Forwarded-Proto.
+func BaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
+
+// AbsoluteURL — ссылка ref от базового адреса base. Уже абсолютная ссылка
+// (например, на CDN из S3_PUBLIC_URL) возвращается как есть.
+func AbsoluteURL(base, ref string) string {
+	if strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") || strings.HasPrefix(ref, "//") {
+		return ref
+	}
+	return base + ref
+}
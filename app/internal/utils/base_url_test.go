@@ -0,0 +1,20 @@
+package utils
+
+import "testing"
+
+func TestAbsoluteURL(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"/static/upload/a.jpg", "http://market.local/static/upload/a.jpg"},
+		{"https://cdn.example.com/a.jpg", "https://cdn.example.com/a.jpg"},
+		{"http://minio:9000/images/a.jpg", "http://minio:9000/images/a.jpg"},
+		{"//cdn.example.com/a.jpg", "//cdn.example.com/a.jpg"},
+	}
+	for _, tt := range tests {
+		if got := AbsoluteURL("http://market.local", tt.ref); got != tt.want {
+			t.Errorf("AbsoluteURL(%q) = %q, want %q", tt.ref, got, tt.want)
+		}
+	}
+}
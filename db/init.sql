@@ -1,5 +1,8 @@
 -- create database vk;
 
+-- Триграммы для поиска похожих объявлений
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
 
 -- Таблица пользователей
 CREATE TABLE IF NOT EXISTS users (
@@ -7,8 +10,11 @@ CREATE TABLE IF NOT EXISTS users (
                                      username TEXT NOT NULL UNIQUE,
                                      email TEXT NOT NULL UNIQUE,
                                      password_hash TEXT NOT NULL,
+                                     phone TEXT,
                                      created_at TIMESTAMP NOT NULL DEFAULT now()
 );
+-- Колонки, добавленные позже: CREATE TABLE IF NOT EXISTS не меняет существующую таблицу
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone TEXT;
 
 -- Таблица объявлений
 CREATE TABLE IF NOT EXISTS ads (
@@ -17,7 +23,53 @@ CREATE TABLE IF NOT EXISTS ads (
                                    description TEXT NOT NULL,
                                    price NUMERIC NOT NULL,
                                    created_at TIMESTAMP NOT NULL DEFAULT now(),
-                                   author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE
+                                   author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                   hidden BOOLEAN NOT NULL DEFAULT false,
+                                   publish_at TIMESTAMP,
+                                   published BOOLEAN NOT NULL DEFAULT true,
+                                   contact_phone TEXT,
+                                   quantity INT NOT NULL DEFAULT 1 CHECK (quantity >= 0),
+                                   images_private BOOLEAN NOT NULL DEFAULT false
+);
+ALTER TABLE ads ADD COLUMN IF NOT EXISTS hidden BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE ads ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP;
+ALTER TABLE ads ADD COLUMN IF NOT EXISTS published BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE ads ADD COLUMN IF NOT EXISTS contact_phone TEXT;
+ALTER TABLE ads ADD COLUMN IF NOT EXISTS quantity INT NOT NULL DEFAULT 1 CHECK (quantity >= 0);
+ALTER TABLE ads ADD COLUMN IF NOT EXISTS images_private BOOLEAN NOT NULL DEFAULT false;
+CREATE INDEX IF NOT EXISTS ads_title_trgm_idx ON ads USING GIN (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS ads_publish_due_idx ON ads (publish_at) WHERE published = false;
+
+-- Показы телефона продавца: каждый запрос полного номера, для статистики владельца
+CREATE TABLE IF NOT EXISTS contact_reveals (
+                                               id BIGSERIAL PRIMARY KEY,
+                                               ad_id UUID NOT NULL REFERENCES ads(id) ON DELETE CASCADE,
+                                               user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                               created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS contact_reveals_ad_id_idx ON contact_reveals (ad_id);
+
+-- Теги объявлений (хранятся нормализованными: нижний регистр, одиночные пробелы)
+CREATE TABLE IF NOT EXISTS tags (
+                                    id BIGSERIAL PRIMARY KEY,
+                                    name TEXT NOT NULL UNIQUE
+);
+CREATE INDEX IF NOT EXISTS tags_name_prefix_idx ON tags (name text_pattern_ops);
+
+CREATE TABLE IF NOT EXISTS ad_tags (
+                                       ad_id UUID NOT NULL REFERENCES ads(id) ON DELETE CASCADE,
+                                       tag_id BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+                                       created_at TIMESTAMP NOT NULL DEFAULT clock_timestamp(),
+                                       PRIMARY KEY (ad_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS ad_tags_tag_id_idx ON ad_tags (tag_id);
+
+-- Файлы изображений по SHA-256 содержимого: одинаковые загрузки делят одни файлы,
+-- refs — сколько строк ad_images на них ссылается
+CREATE TABLE IF NOT EXISTS image_blobs (
+                                           hash TEXT PRIMARY KEY,
+                                           refs INT NOT NULL DEFAULT 0 CHECK (refs >= 0),
+                                           created_at TIMESTAMP NOT NULL DEFAULT now()
 );
 
 -- Таблица изображений объявлений
@@ -25,8 +77,17 @@ CREATE TABLE IF NOT EXISTS ad_images (
                                          id UUID PRIMARY KEY,
                                          ad_id UUID NOT NULL REFERENCES ads(id) ON DELETE CASCADE,
                                          image_url TEXT NOT NULL,
-                                         created_at TIMESTAMP NOT NULL DEFAULT now()
+                                         created_at TIMESTAMP NOT NULL DEFAULT now(),
+                                         variants JSONB NOT NULL DEFAULT '{}',
+                                         position INT NOT NULL DEFAULT 0,
+                                         size_bytes BIGINT NOT NULL DEFAULT 0,
+                                         hash TEXT REFERENCES image_blobs(hash)
 );
+ALTER TABLE ad_images ADD COLUMN IF NOT EXISTS variants JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE ad_images ADD COLUMN IF NOT EXISTS position INT NOT NULL DEFAULT 0;
+ALTER TABLE ad_images ADD COLUMN IF NOT EXISTS size_bytes BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE ad_images ADD COLUMN IF NOT EXISTS hash TEXT REFERENCES image_blobs(hash);
+CREATE INDEX IF NOT EXISTS ad_images_hash_idx ON ad_images (hash);
 
 
 
@@ -37,3 +98,47 @@ CREATE TABLE IF NOT EXISTS sessions (
                                         created_at TIMESTAMP NOT NULL DEFAULT now(),
                                         expires_at TIMESTAMP NOT NULL
 );
+
+-- Таблица предложений цены (торг)
+CREATE TABLE IF NOT EXISTS offers (
+                                      id UUID PRIMARY KEY,
+                                      ad_id UUID NOT NULL REFERENCES ads(id) ON DELETE CASCADE,
+                                      buyer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                      amount NUMERIC NOT NULL,
+                                      message TEXT NOT NULL DEFAULT '',
+                                      counter_amount NUMERIC,
+                                      counter_message TEXT NOT NULL DEFAULT '',
+                                      hide_ad BOOLEAN NOT NULL DEFAULT false,
+                                      status TEXT NOT NULL,
+                                      created_at TIMESTAMP NOT NULL DEFAULT now(),
+                                      updated_at TIMESTAMP NOT NULL DEFAULT now(),
+                                      expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS offers_ad_id_idx ON offers (ad_id, status);
+
+-- Таблица заказов (сделки)
+CREATE TABLE IF NOT EXISTS orders (
+                                      id UUID PRIMARY KEY,
+                                      ad_id UUID REFERENCES ads(id) ON DELETE SET NULL,
+                                      buyer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                      seller_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                      amount NUMERIC NOT NULL,
+                                      status TEXT NOT NULL,
+                                      payment_id TEXT NOT NULL DEFAULT '',
+                                      created_at TIMESTAMP NOT NULL DEFAULT now(),
+                                      updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- Журнал переходов заказа между статусами
+CREATE TABLE IF NOT EXISTS order_events (
+                                            id BIGSERIAL PRIMARY KEY,
+                                            order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+                                            from_status TEXT NOT NULL DEFAULT '',
+                                            to_status TEXT NOT NULL,
+                                            actor_id UUID,
+                                            note TEXT NOT NULL DEFAULT '',
+                                            created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS order_events_order_id_idx ON order_events (order_id, id);